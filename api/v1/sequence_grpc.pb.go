@@ -0,0 +1,127 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: api/v1/sequence.proto
+
+package api_v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SequenceService_Next_FullMethodName = "/api.v1.SequenceService/Next"
+)
+
+// SequenceServiceClient is the client API for SequenceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SequenceServiceClient interface {
+	// Next reserves strictly increasing IDs from a named sequence. IDs start at 1 and are
+	// never handed out twice, but the server leases IDs in blocks and a crash skips the
+	// unused rest of a block, so callers must accept gaps between IDs
+	Next(ctx context.Context, in *NextRequest, opts ...grpc.CallOption) (*NextResponse, error)
+}
+
+type sequenceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSequenceServiceClient(cc grpc.ClientConnInterface) SequenceServiceClient {
+	return &sequenceServiceClient{cc}
+}
+
+func (c *sequenceServiceClient) Next(ctx context.Context, in *NextRequest, opts ...grpc.CallOption) (*NextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NextResponse)
+	err := c.cc.Invoke(ctx, SequenceService_Next_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SequenceServiceServer is the server API for SequenceService service.
+// All implementations must embed UnimplementedSequenceServiceServer
+// for forward compatibility.
+type SequenceServiceServer interface {
+	// Next reserves strictly increasing IDs from a named sequence. IDs start at 1 and are
+	// never handed out twice, but the server leases IDs in blocks and a crash skips the
+	// unused rest of a block, so callers must accept gaps between IDs
+	Next(context.Context, *NextRequest) (*NextResponse, error)
+	mustEmbedUnimplementedSequenceServiceServer()
+}
+
+// UnimplementedSequenceServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSequenceServiceServer struct{}
+
+func (UnimplementedSequenceServiceServer) Next(context.Context, *NextRequest) (*NextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Next not implemented")
+}
+func (UnimplementedSequenceServiceServer) mustEmbedUnimplementedSequenceServiceServer() {}
+func (UnimplementedSequenceServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeSequenceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SequenceServiceServer will
+// result in compilation errors.
+type UnsafeSequenceServiceServer interface {
+	mustEmbedUnimplementedSequenceServiceServer()
+}
+
+func RegisterSequenceServiceServer(s grpc.ServiceRegistrar, srv SequenceServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSequenceServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SequenceService_ServiceDesc, srv)
+}
+
+func _SequenceService_Next_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SequenceServiceServer).Next(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SequenceService_Next_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SequenceServiceServer).Next(ctx, req.(*NextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SequenceService_ServiceDesc is the grpc.ServiceDesc for SequenceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SequenceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.v1.SequenceService",
+	HandlerType: (*SequenceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Next",
+			Handler:    _SequenceService_Next_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/sequence.proto",
+}
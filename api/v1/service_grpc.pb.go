@@ -19,7 +19,28 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	IncrementService_Increment_FullMethodName = "/api.v1.IncrementService/Increment"
+	IncrementService_Increment_FullMethodName             = "/api.v1.IncrementService/Increment"
+	IncrementService_CreateCounter_FullMethodName         = "/api.v1.IncrementService/CreateCounter"
+	IncrementService_Add_FullMethodName                   = "/api.v1.IncrementService/Add"
+	IncrementService_Get_FullMethodName                   = "/api.v1.IncrementService/Get"
+	IncrementService_Set_FullMethodName                   = "/api.v1.IncrementService/Set"
+	IncrementService_Reset_FullMethodName                 = "/api.v1.IncrementService/Reset"
+	IncrementService_DeleteCounter_FullMethodName         = "/api.v1.IncrementService/DeleteCounter"
+	IncrementService_ListCounters_FullMethodName          = "/api.v1.IncrementService/ListCounters"
+	IncrementService_Watch_FullMethodName                 = "/api.v1.IncrementService/Watch"
+	IncrementService_BatchMutate_FullMethodName           = "/api.v1.IncrementService/BatchMutate"
+	IncrementService_QueryWindow_FullMethodName           = "/api.v1.IncrementService/QueryWindow"
+	IncrementService_UpdateCounterMetadata_FullMethodName = "/api.v1.IncrementService/UpdateCounterMetadata"
+	IncrementService_GetHistory_FullMethodName            = "/api.v1.IncrementService/GetHistory"
+	IncrementService_Export_FullMethodName                = "/api.v1.IncrementService/Export"
+	IncrementService_Import_FullMethodName                = "/api.v1.IncrementService/Import"
+	IncrementService_Aggregate_FullMethodName             = "/api.v1.IncrementService/Aggregate"
+	IncrementService_AddDistinct_FullMethodName           = "/api.v1.IncrementService/AddDistinct"
+	IncrementService_Cardinality_FullMethodName           = "/api.v1.IncrementService/Cardinality"
+	IncrementService_MergeDistinct_FullMethodName         = "/api.v1.IncrementService/MergeDistinct"
+	IncrementService_TopK_FullMethodName                  = "/api.v1.IncrementService/TopK"
+	IncrementService_Rank_FullMethodName                  = "/api.v1.IncrementService/Rank"
+	IncrementService_WatchTopK_FullMethodName             = "/api.v1.IncrementService/WatchTopK"
 )
 
 // IncrementServiceClient is the client API for IncrementService service.
@@ -27,6 +48,64 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type IncrementServiceClient interface {
 	Increment(ctx context.Context, in *IncrementRequest, opts ...grpc.CallOption) (*IncrementResponse, error)
+	// CreateCounter declares a counter with its settings, existing counters return ALREADY_EXISTS
+	CreateCounter(ctx context.Context, in *CreateCounterRequest, opts ...grpc.CallOption) (*Counter, error)
+	// Add applies a signed delta to a counter, results outside the uint64 range are rejected with OUT_OF_RANGE
+	Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error)
+	// Get reads a counter without changing it, missing counters return NOT_FOUND
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Counter, error)
+	// Set writes an absolute value, optionally only when the expected value or version matches
+	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*Counter, error)
+	// Reset sets an existing counter back to zero, or to its lower bound, optionally only when the expected value or version matches
+	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*Counter, error)
+	// DeleteCounter removes a counter, unknown names return NOT_FOUND
+	DeleteCounter(ctx context.Context, in *DeleteCounterRequest, opts ...grpc.CallOption) (*DeleteCounterResponse, error)
+	// ListCounters pages through counters in name order
+	ListCounters(ctx context.Context, in *ListCountersRequest, opts ...grpc.CallOption) (*ListCountersResponse, error)
+	// Watch streams the current state of a counter or of every counter under a prefix, then each change to them,
+	// the stream ends with UNAVAILABLE when the server shuts down, resume with the last revision
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error)
+	// BatchMutate applies several mutations in one transaction, either all of them are applied or none is
+	BatchMutate(ctx context.Context, in *BatchMutateRequest, opts ...grpc.CallOption) (*BatchMutateResponse, error)
+	// QueryWindow sums the changes of a windowed counter over a time range, each increment or add
+	// counts with the delta it requested even when bounds wrap or saturate it, Set, Reset and
+	// Import write a value rather than a change and are not counted
+	QueryWindow(ctx context.Context, in *QueryWindowRequest, opts ...grpc.CallOption) (*QueryWindowResponse, error)
+	// UpdateCounterMetadata changes the labels, description or owner of an existing counter
+	UpdateCounterMetadata(ctx context.Context, in *UpdateCounterMetadataRequest, opts ...grpc.CallOption) (*Counter, error)
+	// GetHistory lists the recorded writes and the deletes of a counter oldest first one page at a time
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// Export streams every counter under a prefix in name order from a single consistent read
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportResponse], error)
+	// Import reads a stream of counters, validates all of them and then writes them,
+	// nothing is written when any counter is invalid. An import is not atomic, counters are
+	// written 500 per transaction and a failed write leaves the counters before it written
+	// and returns an error carrying an ErrorInfo with reason IMPORT_INCOMPLETE and the index
+	// of the first unwritten counter, and an ImportResponse with the results of the counters
+	// written before it
+	Import(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportRequest, ImportResponse], error)
+	// Aggregate sums the counters matching a label selector, optionally grouped by a label,
+	// matching counters of other types than uint64 are left out and counted as skipped
+	Aggregate(ctx context.Context, in *AggregateRequest, opts ...grpc.CallOption) (*AggregateResponse, error)
+	// AddDistinct adds items to a distinct counter in one transaction, creating it when it does
+	// not exist, adding an item that was already added leaves the estimate unchanged
+	AddDistinct(ctx context.Context, in *AddDistinctRequest, opts ...grpc.CallOption) (*AddDistinctResponse, error)
+	// Cardinality estimates how many distinct items were added to one or more distinct counters
+	Cardinality(ctx context.Context, in *CardinalityRequest, opts ...grpc.CallOption) (*CardinalityResponse, error)
+	// MergeDistinct merges the sketches of distinct counters into another one, creating it when
+	// it does not exist
+	MergeDistinct(ctx context.Context, in *MergeDistinctRequest, opts ...grpc.CallOption) (*Counter, error)
+	// TopK returns the highest counters under a prefix in descending order, only uint64
+	// counters are ranked
+	TopK(ctx context.Context, in *TopKRequest, opts ...grpc.CallOption) (*TopKResponse, error)
+	// Rank returns the position of a counter among the counters under a prefix, counters of
+	// other types than uint64 fail with FAILED_PRECONDITION and counters with more than 10000
+	// higher counters fail with OUT_OF_RANGE, use TopK to read the top of a leaderboard
+	Rank(ctx context.Context, in *RankRequest, opts ...grpc.CallOption) (*RankResponse, error)
+	// WatchTopK streams the highest counters under a prefix, then the new leaderboard every
+	// time it changes, changes arriving while the client reads slowly are folded together,
+	// the stream ends with UNAVAILABLE when the server shuts down
+	WatchTopK(ctx context.Context, in *TopKRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TopKResponse], error)
 }
 
 type incrementServiceClient struct {
@@ -47,11 +126,309 @@ func (c *incrementServiceClient) Increment(ctx context.Context, in *IncrementReq
 	return out, nil
 }
 
+func (c *incrementServiceClient) CreateCounter(ctx context.Context, in *CreateCounterRequest, opts ...grpc.CallOption) (*Counter, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Counter)
+	err := c.cc.Invoke(ctx, IncrementService_CreateCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddResponse)
+	err := c.cc.Invoke(ctx, IncrementService_Add_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Counter, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Counter)
+	err := c.cc.Invoke(ctx, IncrementService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*Counter, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Counter)
+	err := c.cc.Invoke(ctx, IncrementService_Set_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*Counter, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Counter)
+	err := c.cc.Invoke(ctx, IncrementService_Reset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) DeleteCounter(ctx context.Context, in *DeleteCounterRequest, opts ...grpc.CallOption) (*DeleteCounterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCounterResponse)
+	err := c.cc.Invoke(ctx, IncrementService_DeleteCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) ListCounters(ctx context.Context, in *ListCountersRequest, opts ...grpc.CallOption) (*ListCountersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCountersResponse)
+	err := c.cc.Invoke(ctx, IncrementService_ListCounters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &IncrementService_ServiceDesc.Streams[0], IncrementService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IncrementService_WatchClient = grpc.ServerStreamingClient[WatchEvent]
+
+func (c *incrementServiceClient) BatchMutate(ctx context.Context, in *BatchMutateRequest, opts ...grpc.CallOption) (*BatchMutateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchMutateResponse)
+	err := c.cc.Invoke(ctx, IncrementService_BatchMutate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) QueryWindow(ctx context.Context, in *QueryWindowRequest, opts ...grpc.CallOption) (*QueryWindowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryWindowResponse)
+	err := c.cc.Invoke(ctx, IncrementService_QueryWindow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) UpdateCounterMetadata(ctx context.Context, in *UpdateCounterMetadataRequest, opts ...grpc.CallOption) (*Counter, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Counter)
+	err := c.cc.Invoke(ctx, IncrementService_UpdateCounterMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, IncrementService_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &IncrementService_ServiceDesc.Streams[1], IncrementService_Export_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRequest, ExportResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IncrementService_ExportClient = grpc.ServerStreamingClient[ExportResponse]
+
+func (c *incrementServiceClient) Import(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportRequest, ImportResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &IncrementService_ServiceDesc.Streams[2], IncrementService_Import_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportRequest, ImportResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IncrementService_ImportClient = grpc.ClientStreamingClient[ImportRequest, ImportResponse]
+
+func (c *incrementServiceClient) Aggregate(ctx context.Context, in *AggregateRequest, opts ...grpc.CallOption) (*AggregateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AggregateResponse)
+	err := c.cc.Invoke(ctx, IncrementService_Aggregate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) AddDistinct(ctx context.Context, in *AddDistinctRequest, opts ...grpc.CallOption) (*AddDistinctResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddDistinctResponse)
+	err := c.cc.Invoke(ctx, IncrementService_AddDistinct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) Cardinality(ctx context.Context, in *CardinalityRequest, opts ...grpc.CallOption) (*CardinalityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CardinalityResponse)
+	err := c.cc.Invoke(ctx, IncrementService_Cardinality_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) MergeDistinct(ctx context.Context, in *MergeDistinctRequest, opts ...grpc.CallOption) (*Counter, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Counter)
+	err := c.cc.Invoke(ctx, IncrementService_MergeDistinct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) TopK(ctx context.Context, in *TopKRequest, opts ...grpc.CallOption) (*TopKResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopKResponse)
+	err := c.cc.Invoke(ctx, IncrementService_TopK_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) Rank(ctx context.Context, in *RankRequest, opts ...grpc.CallOption) (*RankResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RankResponse)
+	err := c.cc.Invoke(ctx, IncrementService_Rank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *incrementServiceClient) WatchTopK(ctx context.Context, in *TopKRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TopKResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &IncrementService_ServiceDesc.Streams[3], IncrementService_WatchTopK_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TopKRequest, TopKResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IncrementService_WatchTopKClient = grpc.ServerStreamingClient[TopKResponse]
+
 // IncrementServiceServer is the server API for IncrementService service.
 // All implementations must embed UnimplementedIncrementServiceServer
 // for forward compatibility.
 type IncrementServiceServer interface {
 	Increment(context.Context, *IncrementRequest) (*IncrementResponse, error)
+	// CreateCounter declares a counter with its settings, existing counters return ALREADY_EXISTS
+	CreateCounter(context.Context, *CreateCounterRequest) (*Counter, error)
+	// Add applies a signed delta to a counter, results outside the uint64 range are rejected with OUT_OF_RANGE
+	Add(context.Context, *AddRequest) (*AddResponse, error)
+	// Get reads a counter without changing it, missing counters return NOT_FOUND
+	Get(context.Context, *GetRequest) (*Counter, error)
+	// Set writes an absolute value, optionally only when the expected value or version matches
+	Set(context.Context, *SetRequest) (*Counter, error)
+	// Reset sets an existing counter back to zero, or to its lower bound, optionally only when the expected value or version matches
+	Reset(context.Context, *ResetRequest) (*Counter, error)
+	// DeleteCounter removes a counter, unknown names return NOT_FOUND
+	DeleteCounter(context.Context, *DeleteCounterRequest) (*DeleteCounterResponse, error)
+	// ListCounters pages through counters in name order
+	ListCounters(context.Context, *ListCountersRequest) (*ListCountersResponse, error)
+	// Watch streams the current state of a counter or of every counter under a prefix, then each change to them,
+	// the stream ends with UNAVAILABLE when the server shuts down, resume with the last revision
+	Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error
+	// BatchMutate applies several mutations in one transaction, either all of them are applied or none is
+	BatchMutate(context.Context, *BatchMutateRequest) (*BatchMutateResponse, error)
+	// QueryWindow sums the changes of a windowed counter over a time range, each increment or add
+	// counts with the delta it requested even when bounds wrap or saturate it, Set, Reset and
+	// Import write a value rather than a change and are not counted
+	QueryWindow(context.Context, *QueryWindowRequest) (*QueryWindowResponse, error)
+	// UpdateCounterMetadata changes the labels, description or owner of an existing counter
+	UpdateCounterMetadata(context.Context, *UpdateCounterMetadataRequest) (*Counter, error)
+	// GetHistory lists the recorded writes and the deletes of a counter oldest first one page at a time
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// Export streams every counter under a prefix in name order from a single consistent read
+	Export(*ExportRequest, grpc.ServerStreamingServer[ExportResponse]) error
+	// Import reads a stream of counters, validates all of them and then writes them,
+	// nothing is written when any counter is invalid. An import is not atomic, counters are
+	// written 500 per transaction and a failed write leaves the counters before it written
+	// and returns an error carrying an ErrorInfo with reason IMPORT_INCOMPLETE and the index
+	// of the first unwritten counter, and an ImportResponse with the results of the counters
+	// written before it
+	Import(grpc.ClientStreamingServer[ImportRequest, ImportResponse]) error
+	// Aggregate sums the counters matching a label selector, optionally grouped by a label,
+	// matching counters of other types than uint64 are left out and counted as skipped
+	Aggregate(context.Context, *AggregateRequest) (*AggregateResponse, error)
+	// AddDistinct adds items to a distinct counter in one transaction, creating it when it does
+	// not exist, adding an item that was already added leaves the estimate unchanged
+	AddDistinct(context.Context, *AddDistinctRequest) (*AddDistinctResponse, error)
+	// Cardinality estimates how many distinct items were added to one or more distinct counters
+	Cardinality(context.Context, *CardinalityRequest) (*CardinalityResponse, error)
+	// MergeDistinct merges the sketches of distinct counters into another one, creating it when
+	// it does not exist
+	MergeDistinct(context.Context, *MergeDistinctRequest) (*Counter, error)
+	// TopK returns the highest counters under a prefix in descending order, only uint64
+	// counters are ranked
+	TopK(context.Context, *TopKRequest) (*TopKResponse, error)
+	// Rank returns the position of a counter among the counters under a prefix, counters of
+	// other types than uint64 fail with FAILED_PRECONDITION and counters with more than 10000
+	// higher counters fail with OUT_OF_RANGE, use TopK to read the top of a leaderboard
+	Rank(context.Context, *RankRequest) (*RankResponse, error)
+	// WatchTopK streams the highest counters under a prefix, then the new leaderboard every
+	// time it changes, changes arriving while the client reads slowly are folded together,
+	// the stream ends with UNAVAILABLE when the server shuts down
+	WatchTopK(*TopKRequest, grpc.ServerStreamingServer[TopKResponse]) error
 	mustEmbedUnimplementedIncrementServiceServer()
 }
 
@@ -65,6 +442,69 @@ type UnimplementedIncrementServiceServer struct{}
 func (UnimplementedIncrementServiceServer) Increment(context.Context, *IncrementRequest) (*IncrementResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Increment not implemented")
 }
+func (UnimplementedIncrementServiceServer) CreateCounter(context.Context, *CreateCounterRequest) (*Counter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCounter not implemented")
+}
+func (UnimplementedIncrementServiceServer) Add(context.Context, *AddRequest) (*AddResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
+}
+func (UnimplementedIncrementServiceServer) Get(context.Context, *GetRequest) (*Counter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedIncrementServiceServer) Set(context.Context, *SetRequest) (*Counter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+}
+func (UnimplementedIncrementServiceServer) Reset(context.Context, *ResetRequest) (*Counter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
+}
+func (UnimplementedIncrementServiceServer) DeleteCounter(context.Context, *DeleteCounterRequest) (*DeleteCounterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCounter not implemented")
+}
+func (UnimplementedIncrementServiceServer) ListCounters(context.Context, *ListCountersRequest) (*ListCountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCounters not implemented")
+}
+func (UnimplementedIncrementServiceServer) Watch(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedIncrementServiceServer) BatchMutate(context.Context, *BatchMutateRequest) (*BatchMutateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchMutate not implemented")
+}
+func (UnimplementedIncrementServiceServer) QueryWindow(context.Context, *QueryWindowRequest) (*QueryWindowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryWindow not implemented")
+}
+func (UnimplementedIncrementServiceServer) UpdateCounterMetadata(context.Context, *UpdateCounterMetadataRequest) (*Counter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCounterMetadata not implemented")
+}
+func (UnimplementedIncrementServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedIncrementServiceServer) Export(*ExportRequest, grpc.ServerStreamingServer[ExportResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedIncrementServiceServer) Import(grpc.ClientStreamingServer[ImportRequest, ImportResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
+func (UnimplementedIncrementServiceServer) Aggregate(context.Context, *AggregateRequest) (*AggregateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Aggregate not implemented")
+}
+func (UnimplementedIncrementServiceServer) AddDistinct(context.Context, *AddDistinctRequest) (*AddDistinctResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDistinct not implemented")
+}
+func (UnimplementedIncrementServiceServer) Cardinality(context.Context, *CardinalityRequest) (*CardinalityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cardinality not implemented")
+}
+func (UnimplementedIncrementServiceServer) MergeDistinct(context.Context, *MergeDistinctRequest) (*Counter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeDistinct not implemented")
+}
+func (UnimplementedIncrementServiceServer) TopK(context.Context, *TopKRequest) (*TopKResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopK not implemented")
+}
+func (UnimplementedIncrementServiceServer) Rank(context.Context, *RankRequest) (*RankResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rank not implemented")
+}
+func (UnimplementedIncrementServiceServer) WatchTopK(*TopKRequest, grpc.ServerStreamingServer[TopKResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTopK not implemented")
+}
 func (UnimplementedIncrementServiceServer) mustEmbedUnimplementedIncrementServiceServer() {}
 func (UnimplementedIncrementServiceServer) testEmbeddedByValue()                          {}
 
@@ -104,6 +544,352 @@ func _IncrementService_Increment_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IncrementService_CreateCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCounterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).CreateCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_CreateCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).CreateCounter(ctx, req.(*CreateCounterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_Add_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).Add(ctx, req.(*AddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_Set_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).Set(ctx, req.(*SetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_Reset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).Reset(ctx, req.(*ResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_DeleteCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCounterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).DeleteCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_DeleteCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).DeleteCounter(ctx, req.(*DeleteCounterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_ListCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCountersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).ListCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_ListCounters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).ListCounters(ctx, req.(*ListCountersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IncrementServiceServer).Watch(m, &grpc.GenericServerStream[WatchRequest, WatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IncrementService_WatchServer = grpc.ServerStreamingServer[WatchEvent]
+
+func _IncrementService_BatchMutate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchMutateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).BatchMutate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_BatchMutate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).BatchMutate(ctx, req.(*BatchMutateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_QueryWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).QueryWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_QueryWindow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).QueryWindow(ctx, req.(*QueryWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_UpdateCounterMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCounterMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).UpdateCounterMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_UpdateCounterMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).UpdateCounterMetadata(ctx, req.(*UpdateCounterMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IncrementServiceServer).Export(m, &grpc.GenericServerStream[ExportRequest, ExportResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IncrementService_ExportServer = grpc.ServerStreamingServer[ExportResponse]
+
+func _IncrementService_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(IncrementServiceServer).Import(&grpc.GenericServerStream[ImportRequest, ImportResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IncrementService_ImportServer = grpc.ClientStreamingServer[ImportRequest, ImportResponse]
+
+func _IncrementService_Aggregate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).Aggregate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_Aggregate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).Aggregate(ctx, req.(*AggregateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_AddDistinct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDistinctRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).AddDistinct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_AddDistinct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).AddDistinct(ctx, req.(*AddDistinctRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_Cardinality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CardinalityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).Cardinality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_Cardinality_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).Cardinality(ctx, req.(*CardinalityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_MergeDistinct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeDistinctRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).MergeDistinct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_MergeDistinct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).MergeDistinct(ctx, req.(*MergeDistinctRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_TopK_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopKRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).TopK(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_TopK_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).TopK(ctx, req.(*TopKRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_Rank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IncrementServiceServer).Rank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IncrementService_Rank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IncrementServiceServer).Rank(ctx, req.(*RankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IncrementService_WatchTopK_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TopKRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IncrementServiceServer).WatchTopK(m, &grpc.GenericServerStream[TopKRequest, TopKResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IncrementService_WatchTopKServer = grpc.ServerStreamingServer[TopKResponse]
+
 // IncrementService_ServiceDesc is the grpc.ServiceDesc for IncrementService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +901,96 @@ var IncrementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Increment",
 			Handler:    _IncrementService_Increment_Handler,
 		},
+		{
+			MethodName: "CreateCounter",
+			Handler:    _IncrementService_CreateCounter_Handler,
+		},
+		{
+			MethodName: "Add",
+			Handler:    _IncrementService_Add_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _IncrementService_Get_Handler,
+		},
+		{
+			MethodName: "Set",
+			Handler:    _IncrementService_Set_Handler,
+		},
+		{
+			MethodName: "Reset",
+			Handler:    _IncrementService_Reset_Handler,
+		},
+		{
+			MethodName: "DeleteCounter",
+			Handler:    _IncrementService_DeleteCounter_Handler,
+		},
+		{
+			MethodName: "ListCounters",
+			Handler:    _IncrementService_ListCounters_Handler,
+		},
+		{
+			MethodName: "BatchMutate",
+			Handler:    _IncrementService_BatchMutate_Handler,
+		},
+		{
+			MethodName: "QueryWindow",
+			Handler:    _IncrementService_QueryWindow_Handler,
+		},
+		{
+			MethodName: "UpdateCounterMetadata",
+			Handler:    _IncrementService_UpdateCounterMetadata_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _IncrementService_GetHistory_Handler,
+		},
+		{
+			MethodName: "Aggregate",
+			Handler:    _IncrementService_Aggregate_Handler,
+		},
+		{
+			MethodName: "AddDistinct",
+			Handler:    _IncrementService_AddDistinct_Handler,
+		},
+		{
+			MethodName: "Cardinality",
+			Handler:    _IncrementService_Cardinality_Handler,
+		},
+		{
+			MethodName: "MergeDistinct",
+			Handler:    _IncrementService_MergeDistinct_Handler,
+		},
+		{
+			MethodName: "TopK",
+			Handler:    _IncrementService_TopK_Handler,
+		},
+		{
+			MethodName: "Rank",
+			Handler:    _IncrementService_Rank_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _IncrementService_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _IncrementService_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Import",
+			Handler:       _IncrementService_Import_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchTopK",
+			Handler:       _IncrementService_WatchTopK_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/service.proto",
 }
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os"
 	"path"
+	"time"
 
 	"github.com/bryopsida/go-grpc-server-template/interfaces"
 	"github.com/spf13/viper"
@@ -12,6 +13,7 @@ import (
 
 const (
 	databasePathkey      = "database.path"
+	databaseVersionsKey  = "database.versions_to_keep"
 	serverPortKey        = "server.port"
 	serverAddressKey     = "server.address"
 	serverTLSEnabledKey  = "server.tls.enabled"
@@ -21,6 +23,11 @@ const (
 	serverTLSKeyPathKey  = "server.tls.key_path"
 	serverTLSCaKey       = "server.tls.ca"
 	serverTLSCaPathKey   = "server.tls.ca_path"
+	idempotencyTTLKey    = "idempotency.ttl"
+	rateLimitRulesKey    = "ratelimit.rules"
+	sequenceBandwidthKey = "sequence.bandwidth"
+	historyRetentionKey  = "history.retention"
+	distinctPrecisionKey = "distinct.precision"
 )
 
 type viperConfig struct {
@@ -37,6 +44,7 @@ func NewViperConfig() interfaces.IConfig {
 
 func (c *viperConfig) setDefaults() {
 	c.viper.SetDefault(databasePathkey, path.Join("data", "db"))
+	c.viper.SetDefault(databaseVersionsKey, 1)
 	c.viper.SetDefault(serverPortKey, 50051)
 	c.viper.SetDefault(serverAddressKey, "localhost")
 	c.viper.SetDefault(serverTLSEnabledKey, false)
@@ -46,6 +54,11 @@ func (c *viperConfig) setDefaults() {
 	c.viper.SetDefault(serverTLSKeyPathKey, "")
 	c.viper.SetDefault(serverTLSCaKey, "")
 	c.viper.SetDefault(serverTLSCaPathKey, "")
+	c.viper.SetDefault(idempotencyTTLKey, "24h")
+	c.viper.SetDefault(rateLimitRulesKey, []interfaces.RateLimitRule{})
+	c.viper.SetDefault(sequenceBandwidthKey, 1000)
+	c.viper.SetDefault(historyRetentionKey, "720h")
+	c.viper.SetDefault(distinctPrecisionKey, 14)
 }
 
 func (c *viperConfig) initialize() {
@@ -60,6 +73,12 @@ func (c *viperConfig) GetDatabasePath() string {
 	return c.viper.GetString(databasePathkey)
 }
 
+// GetDatabaseVersionsToKeep returns how many versions of each key the database retains
+// for point in time reads, values below 1 keep one
+func (c *viperConfig) GetDatabaseVersionsToKeep() int {
+	return max(c.viper.GetInt(databaseVersionsKey), 1)
+}
+
 func (c *viperConfig) GetServerPort() uint16 {
 	return uint16(c.viper.GetInt(serverPortKey))
 }
@@ -108,3 +127,35 @@ func (c *viperConfig) GetServerCA() string {
 func (c *viperConfig) IsTLSEnabled() bool {
 	return c.viper.GetBool(serverTLSEnabledKey)
 }
+
+// GetIdempotencyTTL returns how long idempotency records are kept, 0 keeps them forever
+func (c *viperConfig) GetIdempotencyTTL() time.Duration {
+	return c.viper.GetDuration(idempotencyTTLKey)
+}
+
+// GetRateLimitRules returns the rate limit rules in the order they are matched,
+// rules that can not be decoded are logged and none are returned
+func (c *viperConfig) GetRateLimitRules() []interfaces.RateLimitRule {
+	rules := []interfaces.RateLimitRule{}
+	if err := c.viper.UnmarshalKey(rateLimitRulesKey, &rules); err != nil {
+		slog.Warn("Failed to decode rate limit rules", slog.Any("error", err))
+		return nil
+	}
+	return rules
+}
+
+// GetSequenceBandwidth returns how many IDs a sequence leases from the database at once
+func (c *viperConfig) GetSequenceBandwidth() uint64 {
+	return c.viper.GetUint64(sequenceBandwidthKey)
+}
+
+// GetHistoryRetention returns how long counter history entries are kept, 0 keeps them forever
+func (c *viperConfig) GetHistoryRetention() time.Duration {
+	return c.viper.GetDuration(historyRetentionKey)
+}
+
+// GetDistinctPrecision returns the default precision of distinct counter sketches, a sketch
+// of precision p keeps 2^p registers
+func (c *viperConfig) GetDistinctPrecision() uint8 {
+	return c.viper.GetUint8(distinctPrecisionKey)
+}
@@ -3,7 +3,9 @@ package config
 import (
 	"path"
 	"testing"
+	"time"
 
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,3 +28,61 @@ func TestViperConfig_GetDatabasePath(t *testing.T) {
 	expectedPath := path.Join("data", "db")
 	assert.Equal(t, expectedPath, dbPath)
 }
+
+func TestViperConfig_GetIdempotencyTTL(t *testing.T) {
+	// Create a new Viper config
+	config := NewViperConfig()
+
+	// Assert that the idempotency ttl is the default value
+	assert.Equal(t, 24*time.Hour, config.GetIdempotencyTTL())
+}
+
+func TestViperConfig_GetSequenceBandwidth(t *testing.T) {
+	// Create a new Viper config
+	config := NewViperConfig()
+
+	// Assert that the sequence bandwidth is the default value
+	assert.Equal(t, uint64(1000), config.GetSequenceBandwidth())
+}
+
+func TestViperConfig_GetDatabaseVersionsToKeep(t *testing.T) {
+	// Create a new Viper config
+	config := NewViperConfig()
+
+	// Assert that the versions to keep is the default value
+	assert.Equal(t, 1, config.GetDatabaseVersionsToKeep())
+}
+
+func TestViperConfig_GetHistoryRetention(t *testing.T) {
+	// Create a new Viper config
+	config := NewViperConfig()
+
+	// Assert that the history retention is the default value
+	assert.Equal(t, 30*24*time.Hour, config.GetHistoryRetention())
+}
+
+func TestViperConfig_GetDistinctPrecision(t *testing.T) {
+	// Create a new Viper config
+	config := NewViperConfig()
+
+	// Assert that the distinct precision is the default value
+	assert.Equal(t, uint8(14), config.GetDistinctPrecision())
+}
+
+func TestViperConfig_GetRateLimitRules(t *testing.T) {
+	// Create a new Viper config
+	config := NewViperConfig()
+
+	// Assert that there are no rules by default
+	assert.Empty(t, config.GetRateLimitRules())
+
+	// Assert that configured rules are decoded in order
+	config.(*viperConfig).viper.Set(rateLimitRulesKey, []map[string]any{
+		{"pattern": "login/*", "algorithm": "sliding_window_log", "limit": 5, "interval": "1m"},
+		{"pattern": "*", "algorithm": "token_bucket", "limit": 100, "interval": "1s"},
+	})
+	assert.Equal(t, []interfaces.RateLimitRule{
+		{Pattern: "login/*", Algorithm: interfaces.SlidingWindowLog, Limit: 5, Interval: time.Minute},
+		{Pattern: "*", Algorithm: interfaces.TokenBucket, Limit: 100, Interval: time.Second},
+	}, config.GetRateLimitRules())
+}
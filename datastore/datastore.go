@@ -20,6 +20,6 @@ func GetDatabase(config interfaces.IConfig) (*badger.DB, error) {
 			slog.Error("Error creating database directory", "error", err)
 		}
 	}
-	opts := badger.DefaultOptions(config.GetDatabasePath())
+	opts := badger.DefaultOptions(config.GetDatabasePath()).WithNumVersionsToKeep(config.GetDatabaseVersionsToKeep())
 	return badger.Open(opts)
 }
@@ -16,6 +16,7 @@ func TestGetDatabase(t *testing.T) {
 	// Create a mock config
 	mockConfig := new(MockConfig)
 	mockConfig.On("GetDatabasePath").Return(dbPath)
+	mockConfig.On("GetDatabaseVersionsToKeep").Return(5)
 
 	// Call GetDatabase
 	db, err := GetDatabase(mockConfig)
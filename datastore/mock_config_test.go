@@ -1,6 +1,9 @@
 package datastore
 
 import (
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -43,3 +46,33 @@ func (m *MockConfig) IsTLSEnabled() bool {
 	args := m.Called()
 	return args.Bool(0)
 }
+
+func (m *MockConfig) GetIdempotencyTTL() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetRateLimitRules() []interfaces.RateLimitRule {
+	args := m.Called()
+	return args.Get(0).([]interfaces.RateLimitRule)
+}
+
+func (m *MockConfig) GetSequenceBandwidth() uint64 {
+	args := m.Called()
+	return args.Get(0).(uint64)
+}
+
+func (m *MockConfig) GetDatabaseVersionsToKeep() int {
+	args := m.Called()
+	return args.Int(0)
+}
+
+func (m *MockConfig) GetHistoryRetention() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetDistinctPrecision() uint8 {
+	args := m.Called()
+	return args.Get(0).(uint8)
+}
@@ -1,5 +1,29 @@
 package interfaces
 
+import "time"
+
+// RateLimitAlgorithm names the algorithm a rate limit rule uses
+type RateLimitAlgorithm string
+
+const (
+	// TokenBucket refills Limit tokens evenly over every Interval and lets bursts spend them
+	TokenBucket RateLimitAlgorithm = "token_bucket"
+	// SlidingWindowLog admits at most Limit cost during any Interval long window
+	SlidingWindowLog RateLimitAlgorithm = "sliding_window_log"
+)
+
+// RateLimitRule configures the rate limit of the keys matching a pattern
+type RateLimitRule struct {
+	// Pattern is a path.Match pattern matched against the whole key
+	Pattern string `mapstructure:"pattern"`
+	// Algorithm is the algorithm used for matching keys
+	Algorithm RateLimitAlgorithm `mapstructure:"algorithm"`
+	// Limit is the bucket capacity, or the cost admitted per window
+	Limit uint64 `mapstructure:"limit"`
+	// Interval is how long refilling an empty bucket takes, or the length of the window
+	Interval time.Duration `mapstructure:"interval"`
+}
+
 // IConfig is an interface for configuration
 type IConfig interface {
 	// GetDatabasePath returns the database path
@@ -10,4 +34,16 @@ type IConfig interface {
 	GetServerKey() string
 	GetServerCA() string
 	IsTLSEnabled() bool
+	// GetIdempotencyTTL returns how long idempotency records are kept, 0 keeps them forever
+	GetIdempotencyTTL() time.Duration
+	// GetRateLimitRules returns the rate limit rules in the order they are matched
+	GetRateLimitRules() []RateLimitRule
+	// GetSequenceBandwidth returns how many IDs a sequence leases from the database at once
+	GetSequenceBandwidth() uint64
+	// GetDatabaseVersionsToKeep returns how many versions of each key the database retains for point in time reads
+	GetDatabaseVersionsToKeep() int
+	// GetHistoryRetention returns how long counter history entries are kept, 0 keeps them forever
+	GetHistoryRetention() time.Duration
+	// GetDistinctPrecision returns the default precision of distinct counter sketches
+	GetDistinctPrecision() uint8
 }
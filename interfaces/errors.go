@@ -1,12 +1,31 @@
 package interfaces
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 const (
 	// ErrMsgNotFound is the error message for when a resource is not found
 	ErrMsgNotFound = "not found"
 	// ErrMsgSaveFailed is the error message for when a save operation fails
 	ErrMsgSaveFailed = "save failed"
+	// ErrMsgConflict is the error message for when a transaction keeps conflicting with concurrent writers
+	ErrMsgConflict = "conflict"
+	// ErrMsgOutOfRange is the error message for when a result does not fit the value range
+	ErrMsgOutOfRange = "out of range"
+	// ErrMsgVersionMismatch is the error message for when the stored version is not the expected one
+	ErrMsgVersionMismatch = "version mismatch"
+	// ErrMsgPreconditionFailed is the error message for when the stored state does not satisfy a precondition
+	ErrMsgPreconditionFailed = "precondition failed"
+	// ErrMsgAlreadyExists is the error message for when a resource that is being created already exists
+	ErrMsgAlreadyExists = "already exists"
+	// ErrMsgClosed is the error message for when a resource is used after it was closed
+	ErrMsgClosed = "closed"
+	// ErrMsgLocked is the error message for when a lock is held by someone else
+	ErrMsgLocked = "locked"
+	// ErrMsgTypeMismatch is the error message for when an operation does not fit the type of a number
+	ErrMsgTypeMismatch = "type mismatch"
 )
 
 var (
@@ -14,4 +33,38 @@ var (
 	ErrNotFound = errors.New(ErrMsgNotFound)
 	// ErrSaveFailed is an error for when a save operation fails
 	ErrSaveFailed = errors.New(ErrMsgSaveFailed)
+	// ErrConflict is an error for when a transaction keeps conflicting with concurrent writers
+	ErrConflict = errors.New(ErrMsgConflict)
+	// ErrOutOfRange is an error for when a result does not fit the value range
+	ErrOutOfRange = errors.New(ErrMsgOutOfRange)
+	// ErrVersionMismatch is an error for when the stored version is not the expected one
+	ErrVersionMismatch = errors.New(ErrMsgVersionMismatch)
+	// ErrPreconditionFailed is an error for when the stored state does not satisfy a precondition
+	ErrPreconditionFailed = errors.New(ErrMsgPreconditionFailed)
+	// ErrAlreadyExists is an error for when a resource that is being created already exists
+	ErrAlreadyExists = errors.New(ErrMsgAlreadyExists)
+	// ErrClosed is an error for when a resource is used after it was closed
+	ErrClosed = errors.New(ErrMsgClosed)
+	// ErrLocked is an error for when a lock is held by someone else
+	ErrLocked = errors.New(ErrMsgLocked)
+	// ErrTypeMismatch is an error for when an operation does not fit the type of a number
+	ErrTypeMismatch = errors.New(ErrMsgTypeMismatch)
 )
+
+// ConflictError is returned when an update could not be committed before its retries ran out
+type ConflictError struct {
+	// ID is the identifier of the resource that was being updated
+	ID string
+	// Attempts is the number of times the update was tried
+	Attempts int
+}
+
+// Error implements error
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: %s after %d attempts", ErrMsgConflict, e.ID, e.Attempts)
+}
+
+// Is reports whether target is ErrConflict so callers can use errors.Is
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
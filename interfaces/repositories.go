@@ -1,25 +1,410 @@
 package interfaces
 
+import (
+	"context"
+	"time"
+)
+
+// ReservedKeyPrefix starts the keys repositories use for internal records, counter
+// names can not start with it so internal records never show up as numbers
+const ReservedKeyPrefix = "!"
+
+// OverflowPolicy decides what happens when a mutation would leave a number's bounds
+type OverflowPolicy int
+
+const (
+	// OverflowReject fails the mutation with ErrOutOfRange
+	OverflowReject OverflowPolicy = iota
+	// OverflowSaturate clamps the result to the nearest bound
+	OverflowSaturate
+	// OverflowWrap wraps the result around to the other bound
+	OverflowWrap
+)
+
+// NumberType is the kind of value a number holds, it is fixed when the number is created
+type NumberType int
+
+const (
+	// NumberTypeUint64 numbers hold an unsigned count
+	NumberTypeUint64 NumberType = iota
+	// NumberTypeInt64 numbers hold a signed count that can go up and down
+	NumberTypeInt64
+	// NumberTypeFloat64 numbers hold a gauge with a fractional value
+	NumberTypeFloat64
+	// NumberTypeBigInt numbers hold an unsigned count of any size
+	NumberTypeBigInt
+	// NumberTypeDistinct numbers hold a HyperLogLog sketch estimating how many distinct items were added
+	NumberTypeDistinct
+)
+
+// Bounds limits the values a number can take
+type Bounds struct {
+	// Min is the smallest allowed value
+	Min uint64
+	// Max is the largest allowed value
+	Max uint64
+	// Policy decides what happens to results outside of Min and Max
+	Policy OverflowPolicy
+}
+
+// Expiry controls when a number is dropped
+type Expiry struct {
+	// TTL is how long the number lives once its deadline is started
+	TTL time.Duration
+	// Sliding restarts the deadline on every write, otherwise only the first write starts it
+	Sliding bool
+}
+
+// Window keeps the changes of a number summed per time bucket
+type Window struct {
+	// Resolution is the width of a bucket, a whole number of seconds
+	Resolution time.Duration
+	// Retention is how long a bucket is kept once it has closed
+	Retention time.Duration
+}
+
+// WindowBucket is the sum of the changes of a number during one bucket of a window
+type WindowBucket struct {
+	// Start is when the bucket begins, a multiple of the window resolution
+	Start time.Time
+	// Sum is the total change of the number during the bucket
+	Sum int64
+}
+
 // Number is a struct to represent a number
 type Number struct {
 	// ID is the unique identifier of the number
 	ID string
-	// Number is the value of the number
+	// Number is the value of the number, int64 numbers store its two's complement,
+	// float64 numbers its IEEE 754 bits, big integer numbers leave it 0 and distinct
+	// numbers hold the estimate of their sketch
 	Number uint64
+	// Type is the kind of value the number holds
+	Type NumberType `json:",omitempty"`
+	// Big is the decimal value of big integer numbers, empty for other types
+	Big string `json:",omitempty"`
+	// Sketch is the encoded HyperLogLog sketch of distinct numbers, empty for other types
+	Sketch []byte `json:",omitempty"`
+	// Version is incremented by the repository on every write, it is at least 1 for every
+	// stored number, numbers saved before versions were tracked read as version 1
+	Version uint64
+	// UpdatedAt is set by the repository to the time of the last write
+	UpdatedAt time.Time
+	// Bounds limits the values of the number, nil allows the whole uint64 range
+	Bounds *Bounds `json:",omitempty"`
+	// Expiry makes the repository drop the number after a while, nil keeps it forever
+	Expiry *Expiry `json:",omitempty"`
+	// ExpiresAt is set by the repository to when the number is dropped, zero when it never is
+	ExpiresAt time.Time `json:"-"`
+	// Windows lists the time windows every change of the number is summed into
+	Windows []Window `json:",omitempty"`
+	// Change is the delta requested by the current write, it is summed into the windows and not
+	// stored, writes that set the value leave it 0 so they count as no change
+	Change int64 `json:"-"`
+	// Labels are free form key value pairs describing the number
+	Labels map[string]string `json:",omitempty"`
+	// Description explains what the number counts
+	Description string `json:",omitempty"`
+	// Owner names who is responsible for the number
+	Owner string `json:",omitempty"`
+	// CreatedAt is set by the repository on the first write, zero for numbers written
+	// before it was tracked
+	CreatedAt time.Time
+	// Recreated is set by the repository when the first write of the number found an earlier
+	// deleted or expired number under the same ID, its version then restarted at 1
+	Recreated bool `json:",omitempty"`
+	// Source describes who is making the current change, it is recorded in the
+	// history entry of the write and not stored with the number
+	Source ChangeSource `json:"-"`
+}
+
+// ChangeSource describes who made a change to a number
+type ChangeSource struct {
+	// Caller identifies the client that made the change
+	Caller string
+	// RequestID is the ID the client gave the request, empty when it gave none
+	RequestID string
+}
+
+// HistoryEntry is the record of a single write to a number
+type HistoryEntry struct {
+	// ID is the ID of the number that was written
+	ID string
+	// Version is the version of the number after the write
+	Version uint64
+	// Previous is the value before the write, 0 when the write created the number
+	Previous uint64
+	// Value is the value after the write, 0 when the write deleted the number
+	Value uint64
+	// Type is the kind of value the number holds, Previous and Value are encoded like Number.Number
+	Type NumberType `json:",omitempty"`
+	// BigPrevious is the value before the write of a big integer number
+	BigPrevious string `json:",omitempty"`
+	// BigValue is the value after the write of a big integer number
+	BigValue string `json:",omitempty"`
+	// Delta is the change of the value, wrapping writes report the signed difference,
+	// 0 for float64, big integer and distinct numbers
+	Delta int64
+	// Deleted is true when the write deleted the number
+	Deleted bool `json:",omitempty"`
+	// At is the time of the write
+	At time.Time
+	// Caller identifies the client that made the write
+	Caller string `json:",omitempty"`
+	// RequestID is the ID the client gave the request
+	RequestID string `json:",omitempty"`
+}
+
+// NumberEvent describes a change to a number observed by a watch
+type NumberEvent struct {
+	// Number is the number after the change, only the ID is set for deletions
+	Number Number
+	// Deleted is true when the number was deleted
+	Deleted bool
+	// Synced is true for the single event that marks the end of the initial state,
+	// it carries no number
+	Synced bool
+	// Revision is the commit revision of the change, revisions increase across all numbers
+	Revision uint64
+}
+
+// RankEntry is the position of a number on a leaderboard
+type RankEntry struct {
+	// ID is the ID of the number
+	ID string
+	// Value is the value of the number
+	Value uint64
+	// Rank is the 1-based position of the number, numbers with equal values share a rank
+	Rank uint64
+}
+
+// IdempotencyRecord is the stored outcome of a request made with an idempotency key
+type IdempotencyRecord struct {
+	// Fingerprint identifies the request payload the key was first used with
+	Fingerprint string
+	// Value is the number value returned to the first request
+	Value uint64
+	// Type is the kind of value the number held
+	Type NumberType `json:",omitempty"`
+	// Big is the decimal value returned for a big integer number
+	Big string `json:",omitempty"`
+}
+
+// Lease is the stored holder of a lock
+type Lease struct {
+	// Name is the name of the lock
+	Name string
+	// Owner describes the holder, it is informational only
+	Owner string
+	// Token is the fencing token of the acquisition, it grows with every acquisition of the lock
+	Token uint64
+	// ExpiresAt is set by the repository to when the lease is dropped
+	ExpiresAt time.Time `json:"-"`
+}
+
+// INumberTxn reads and writes numbers inside a single repository transaction
+type INumberTxn interface {
+	// Get returns a number as seen by the transaction, including its own writes
+	// - id: the ID of the number to get
+	// Returns the stored number, or a zero value number with the ID set when none exists yet
+	Get(id string) (*Number, error)
+	// Put stages a number to be saved when the transaction commits, number.Change is
+	// added to the current bucket of each of its windows and a history entry
+	// crediting number.Source is staged with it
+	// - number: the number to save, its creation and update time are set by the repository and its
+	// version to one past the stored version unless it is already set higher
+	// Returns an error if the write can not be staged
+	Put(number *Number) error
+	// GetIdempotency returns the record stored for an idempotency key of a number
+	// - id: the ID of the number the key belongs to
+	// - key: the idempotency key
+	// Returns the record, or nil if the key has not been used or its record expired
+	GetIdempotency(id string, key string) (*IdempotencyRecord, error)
+	// PutIdempotency stages the record for an idempotency key of a number
+	// - id: the ID of the number the key belongs to
+	// - key: the idempotency key
+	// - record: the outcome to replay for later requests with the same key
+	// - ttl: how long the record is kept, 0 keeps it forever
+	// Returns an error if the write can not be staged
+	PutIdempotency(id string, key string, record IdempotencyRecord, ttl time.Duration) error
+	// GetLease returns the lease stored for a lock
+	// - name: the name of the lock
+	// Returns the lease, or nil if the lock is free or its lease expired
+	GetLease(name string) (*Lease, error)
+	// PutLease stages a lease, the repository drops it once the ttl passes
+	// - lease: the lease to save, its expiry time is set by the repository
+	// - ttl: how long the lease lasts at least
+	// Returns an error if the write can not be staged
+	PutLease(lease *Lease, ttl time.Duration) error
+	// DeleteLease stages the removal of the lease of a lock
+	// - name: the name of the lock
+	// Returns an error if the delete can not be staged
+	DeleteLease(name string) error
 }
 
 // INumberRepository is an interface for number repositories
 type INumberRepository interface {
-	// Save saves a number
+	// Save saves a number, its version and update time are set by the repository
 	// - number: the number to save
 	// Returns an error if the save operation fails
 	Save(number Number) error
 	// FindByID finds a number by its ID
 	// - id: the ID of the number to find
-	// Returns the number if found, otherwise returns an error
+	// Returns the number if found, ErrNotFound if it does not exist, otherwise returns an error
 	FindByID(id string) (*Number, error)
-	// DeleteByID deletes a number by its ID
+	// Update atomically reads, modifies and saves a number
+	// - id: the ID of the number to update
+	// - fn: called with the stored number, or a zero value number with the ID set when
+	//   none exists yet, changes made to it are saved when fn returns nil
+	// Returns the saved number, the error from fn, or a ConflictError if concurrent
+	// writers kept conflicting until the retries ran out
+	Update(id string, fn func(number *Number) error) (*Number, error)
+	// List returns numbers in ID order
+	// - prefix: only numbers whose ID starts with prefix are returned
+	// - after: only numbers whose ID sorts after this one are returned, empty starts at the beginning
+	// - limit: the maximum number of numbers to return
+	// Returns the matching numbers, or an error if the scan fails
+	List(prefix string, after string, limit int) ([]Number, error)
+	// FindByIDAt finds a number as it was at a revision
+	// - id: the ID of the number to find
+	// - revision: the commit revision to read at, as reported by watches and listings
+	// Returns the number, ErrNotFound if it did not exist at the revision, or ErrOutOfRange
+	// if the revision is ahead of the store or the versions it needs were discarded
+	FindByIDAt(id string, revision uint64) (*Number, error)
+	// ListAt returns numbers in ID order as they were at a revision
+	// - prefix: only numbers whose ID starts with prefix are returned
+	// - after: only numbers whose ID sorts after this one are returned, empty starts at the beginning
+	// - limit: the maximum number of numbers to return
+	// - revision: the commit revision to read at, 0 reads the latest state
+	// Returns the matching numbers and the revision they were read at, or ErrOutOfRange if the
+	// revision is ahead of the store or the versions it needs were discarded
+	ListAt(prefix string, after string, limit int, revision uint64) ([]Number, uint64, error)
+	// ForEach calls fn for every number under a prefix in ID order from a single consistent read
+	// - prefix: only numbers whose ID starts with prefix are visited
+	// - fn: called for every number with the revision it was read at, returning an error stops the iteration
+	// Returns the error from fn or the scan
+	ForEach(prefix string, fn func(number Number, revision uint64) error) error
+	// ForEachLabeled calls fn for every number carrying a label, found through the label index
+	// from a single consistent read
+	// - key: the label key
+	// - value: the label value to match, nil matches any value
+	// - fn: called for every number with the revision it was read at, returning an error stops the iteration
+	// Returns the error from fn or the scan
+	ForEachLabeled(key string, value *string, fn func(number Number, revision uint64) error) error
+	// ListIDs returns number IDs in order without reading their values
+	// - prefix: only IDs that start with prefix are returned
+	// - after: only IDs that sort after this one are returned, empty starts at the beginning
+	// - limit: the maximum number of IDs to return
+	// Returns the matching IDs, or an error if the scan fails
+	ListIDs(prefix string, after string, limit int) ([]string, error)
+	// Watch sends the current state of numbers and then every change to them until ctx is done
+	// - ctx: the context that ends the watch
+	// - prefix: only numbers whose ID starts with prefix are watched
+	// - since: only numbers changed after this revision are part of the initial state, 0 sends all
+	// - fn: called for every event in revision order, it is called from the store's
+	//   notification goroutine and must not block
+	// Returns the error from fn, or nil once ctx is done
+	Watch(ctx context.Context, prefix string, since uint64, fn func(event NumberEvent) error) error
+	// TopK returns the highest uint64 numbers of a leaderboard from the rank index
+	// - prefix: the leaderboard, empty for all numbers or a prefix ending in a slash
+	// - k: the maximum number of entries to return
+	// Returns the entries in descending value order with ties in ID order, and the revision they were read at
+	TopK(prefix string, k int) ([]RankEntry, uint64, error)
+	// Rank returns the position of a number on a leaderboard from the rank index, the cost
+	// grows with the number of higher numbers so only the first 10000 are counted
+	// - id: the ID of the number
+	// - prefix: the leaderboard, empty for all numbers or a prefix of the ID ending in a slash
+	// Returns the entry of the number and the revision it was read at, ErrNotFound if the number
+	// does not exist, ErrTypeMismatch if it is not a uint64 number, or ErrOutOfRange if more
+	// than 10000 numbers rank above it
+	Rank(id string, prefix string) (*RankEntry, uint64, error)
+	// WatchTopK sends the highest numbers of a leaderboard and then the new entries every time they change
+	// - ctx: the context that ends the watch
+	// - prefix: the leaderboard, empty for all numbers or a prefix ending in a slash
+	// - k: the maximum number of entries to send
+	// - fn: called with the entries and the revision they were read at, changes arriving
+	//   while it runs are folded into the next call
+	// Returns the error from fn, or nil once ctx is done
+	WatchTopK(ctx context.Context, prefix string, k int, fn func(entries []RankEntry, revision uint64) error) error
+	// Migrate brings the indexes up to date with the numbers saved by earlier releases, it is
+	// safe to call while the repository is in use and returns at once when there is nothing to do
+	// Returns an error if the indexes can not be updated, calling it again resumes the work
+	Migrate() error
+	// Transact runs fn inside a single transaction, either every write it stages is saved or none is
+	// - fn: reads and stages writes through the transaction, returning an error discards the writes
+	// Returns the error from fn, or a ConflictError if concurrent writers kept conflicting
+	// until the retries ran out
+	Transact(fn func(txn INumberTxn) error) error
+	// DeleteByID deletes a number by its ID and stages a history entry for the delete
 	// - id: the ID of the number to delete
+	// - source: who is deleting the number, recorded in the history entry
 	// Returns an error if the delete operation fails
-	DeleteByID(id string) error
+	DeleteByID(id string, source ChangeSource) error
+	// DeleteIf atomically checks and deletes a number, a history entry crediting the
+	// number.Source set by check is staged with the delete
+	// - id: the ID of the number to delete
+	// - check: called with the stored number, the number is only deleted when it returns nil
+	// Returns the deleted number, ErrNotFound if it does not exist, or the error from check
+	DeleteIf(id string, check func(number *Number) error) (*Number, error)
+	// Buckets returns the non empty buckets of a window of a number in time order
+	// - id: the ID of the number
+	// - resolution: the resolution of the window
+	// - from: only buckets starting at or after this time are returned
+	// - to: only buckets starting before this time are returned
+	// Returns the buckets, or an error if the scan fails
+	Buckets(id string, resolution time.Duration, from time.Time, to time.Time) ([]WindowBucket, error)
+	// History returns the recorded writes of a number in time order, entries are kept
+	// after the number is deleted until the history retention drops them
+	// - id: the ID of the number
+	// - from: only entries at or after this time are returned
+	// - to: only entries before this time are returned, zero has no upper limit
+	// - after: only entries recorded after this one are returned, nil starts at from
+	// - limit: the maximum number of entries to return
+	// Returns the entries, or an error if the scan fails
+	History(id string, from time.Time, to time.Time, after *HistoryEntry, limit int) ([]HistoryEntry, error)
+}
+
+// RateLimitEntry is a request admitted by a sliding window log
+type RateLimitEntry struct {
+	// At is when the request was admitted
+	At time.Time
+	// Cost is how much of the limit the request used
+	Cost uint64
+}
+
+// RateLimitState is the stored state of the rate limit of a key
+type RateLimitState struct {
+	// Key is the rate limited key
+	Key string
+	// Tokens is what was left in the bucket at UpdatedAt, used by token buckets
+	Tokens float64 `json:",omitempty"`
+	// Log holds the admitted requests still inside the window, used by sliding window logs
+	Log []RateLimitEntry `json:",omitempty"`
+	// UpdatedAt is set by the caller to when the state was computed, zero for keys not seen yet
+	UpdatedAt time.Time
+}
+
+// IRateLimitRepository is an interface for rate limit state repositories
+type IRateLimitRepository interface {
+	// Update atomically reads, modifies and saves the state of a key
+	// - key: the rate limited key
+	// - ttl: how long the state is kept after this write, 0 keeps it forever
+	// - fn: called with the stored state, or a zero value state with the key set when
+	//   none exists yet, changes made to it are saved when fn returns nil
+	// Returns the saved state, the error from fn, or a ConflictError if concurrent
+	// writers kept conflicting until the retries ran out
+	Update(key string, ttl time.Duration, fn func(state *RateLimitState) error) (*RateLimitState, error)
+}
+
+// ISequenceRepository is an interface for repositories handing out monotonic IDs
+type ISequenceRepository interface {
+	// Next reserves a contiguous range of IDs from a named sequence
+	// - name: the name of the sequence
+	// - count: how many IDs to reserve
+	// Returns the first ID of the range, IDs start at 1 and are never handed out twice,
+	// or ErrClosed once the repository is closed
+	Next(name string, count uint64) (uint64, error)
+	// Close releases the leased but unused IDs of every sequence
+	// Returns the errors from releasing the leases
+	Close() error
 }
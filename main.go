@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"os"
@@ -15,9 +16,16 @@ import (
 	"github.com/bryopsida/go-grpc-server-template/datastore"
 	"github.com/bryopsida/go-grpc-server-template/interfaces"
 	"github.com/bryopsida/go-grpc-server-template/repositories/number"
+	ratelimitrepo "github.com/bryopsida/go-grpc-server-template/repositories/ratelimit"
+	sequencerepo "github.com/bryopsida/go-grpc-server-template/repositories/sequence"
 	"github.com/bryopsida/go-grpc-server-template/services/increment"
+	"github.com/bryopsida/go-grpc-server-template/services/lock"
+	"github.com/bryopsida/go-grpc-server-template/services/ratelimit"
+	"github.com/bryopsida/go-grpc-server-template/services/sequence"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 func serveGrpc(server interfaces.GrpcServer, lis net.Listener) {
@@ -29,13 +37,49 @@ func serveGrpc(server interfaces.GrpcServer, lis net.Listener) {
 	}
 }
 
-func runGrpc(ctx context.Context, server interfaces.GrpcServer, lis net.Listener) {
+func runGrpc(ctx context.Context, server interfaces.GrpcServer, lis net.Listener, closers ...io.Closer) {
 	go serveGrpc(server, lis)
 	// wait for cancel signal
 	<-ctx.Done()
-	// stop the server
+	// stop the server, streams opened through endStreamsOnShutdown(ctx) are already ending
 	slog.Info("Shutting down gRPC server...")
 	server.GracefulStop()
+	// no request is in flight anymore, release what the services hold
+	for _, closer := range closers {
+		if err := closer.Close(); err != nil {
+			slog.Error("failed to close", "error", err)
+		}
+	}
+}
+
+// shutdownStream is a server stream whose context also ends when the server shuts down
+type shutdownStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the stream context, done when the client goes away or the server shuts down
+func (s *shutdownStream) Context() context.Context {
+	return s.ctx
+}
+
+// endStreamsOnShutdown ends server streams once the server shuts down, watches only return
+// when their context is done and GracefulStop waits for every open stream
+// - ctx: context.Context server lifetime, done when the server shuts down
+// Returns grpc.StreamServerInterceptor the interceptor, streams it ends fail with Unavailable so
+// clients reconnect to another server
+func endStreamsOnShutdown(ctx context.Context) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		streamCtx, cancel := context.WithCancel(ss.Context())
+		defer cancel()
+		stop := context.AfterFunc(ctx, cancel)
+		defer stop()
+		err := handler(srv, &shutdownStream{ServerStream: ss, ctx: streamCtx})
+		if ctx.Err() != nil && ss.Context().Err() == nil {
+			return status.Error(codes.Unavailable, "server is shutting down")
+		}
+		return err
+	}
 }
 
 func buildTLSCert(cert, key string) (*tls.Certificate, error) {
@@ -96,17 +140,45 @@ func main() {
 	defer db.Close()
 
 	slog.Info("Getting number repository")
-	repo := number.NewBadgerNumberRepository(db)
+	repo := number.NewBadgerNumberRepository(db, number.WithHistoryRetention(config.GetHistoryRetention()))
+	if err := repo.Migrate(); err != nil {
+		slog.Error("failed to migrate number repository", "error", err)
+		panic(err.Error())
+	}
 
 	slog.Info("Getting increment service")
-	service := increment.NewIncrementService(repo, "counter")
+	service := increment.NewIncrementService(repo, "counter",
+		increment.WithIdempotencyTTL(config.GetIdempotencyTTL()),
+		increment.WithDistinctPrecision(config.GetDistinctPrecision()))
+
+	slog.Info("Getting rate limit service")
+	rateLimitService, err := ratelimit.NewRateLimitService(ratelimitrepo.NewBadgerRateLimitRepository(db), config.GetRateLimitRules())
+	if err != nil {
+		slog.Error("failed to create rate limit service", "error", err)
+		panic(err.Error())
+	}
+
+	slog.Info("Getting sequence service")
+	sequences := sequencerepo.NewBadgerSequenceRepository(db, config.GetSequenceBandwidth())
+	sequenceService := sequence.NewSequenceService(sequences)
+
+	slog.Info("Getting lock service")
+	lockService := lock.NewLockService(repo)
+
+	// Create a context with cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	// ensure this is always called on func exit
+	defer cancel()
 
 	slog.Info("Creating gRPC server")
-	options := buildGrpcOptions(config)
+	options := append(buildGrpcOptions(config), grpc.StreamInterceptor(endStreamsOnShutdown(ctx)))
 	server := buildGrpcServer(options)
 
 	// Register the IncrementService
 	api_v1.RegisterIncrementServiceServer(server, service)
+	api_v1.RegisterRateLimitServiceServer(server, rateLimitService)
+	api_v1.RegisterSequenceServiceServer(server, sequenceService)
+	api_v1.RegisterLockServiceServer(server, lockService)
 
 	// Listen on a port
 	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", config.GetServerAddress(), config.GetServerPort()))
@@ -115,22 +187,22 @@ func main() {
 		panic(err.Error())
 	}
 
-	// Create a context with cancellation
-	ctx, cancel := context.WithCancel(context.Background())
-	// ensure this is always called on func exit
-	defer cancel()
-
 	// Set up signal handling
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
 
-	// Run the server in a goroutine
-	go runGrpc(ctx, server, lis)
+	// Run the server in a goroutine, sequence leases are released once it stopped
+	stopped := make(chan struct{})
+	go func() {
+		runGrpc(ctx, server, lis, sequences)
+		close(stopped)
+	}()
 
 	// Wait for a signal
 	sig := <-sigChan
 	slog.Info("Received signal", "signal", sig)
-	// Cancel the context
+	// Cancel the context and wait for the shutdown before the database is closed
 	cancel()
+	<-stopped
 	slog.Info("Server stopped")
 }
@@ -8,15 +8,24 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
 	"math/big"
 	"net"
 	"testing"
 	"time"
 
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
 	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/repositories/number"
+	"github.com/bryopsida/go-grpc-server-template/services/increment"
+	"github.com/dgraph-io/badger/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 // MockIConfig is a mock of IConfig interface using testify/mock
@@ -426,3 +435,107 @@ func TestRunGrpc(t *testing.T) {
 		})
 	}
 }
+
+// MockCloser is a mock of io.Closer using testify/mock
+type MockCloser struct {
+	mock.Mock
+}
+
+func (m *MockCloser) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func TestRunGrpcClosesAfterStop(t *testing.T) {
+	mockLis := new(MockListener)
+	mockServer := new(MockServer)
+	mockLis.On("Addr").Return(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}).Maybe()
+	mockServer.On("Serve", mockLis).Return(nil).Maybe()
+
+	stopped := false
+	mockServer.On("GracefulStop").Run(func(args mock.Arguments) { stopped = true }).Return()
+	first := new(MockCloser)
+	first.On("Close").Run(func(args mock.Arguments) {
+		assert.True(t, stopped, "closed before the server stopped")
+	}).Return(errors.New("failed"))
+	second := new(MockCloser)
+	second.On("Close").Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	runGrpc(ctx, mockServer, mockLis, first, second)
+
+	mockServer.AssertCalled(t, "GracefulStop")
+	first.AssertExpectations(t)
+	second.AssertExpectations(t)
+}
+
+func TestRunGrpcEndsOpenWatches(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := buildGrpcServer([]grpc.ServerOption{grpc.StreamInterceptor(endStreamsOnShutdown(ctx))})
+	api_v1.RegisterIncrementServiceServer(server, increment.NewIncrementService(number.NewBadgerNumberRepository(db), "counter"))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	closer := new(MockCloser)
+	closer.On("Close").Return(nil)
+	stopped := make(chan struct{})
+	go func() {
+		runGrpc(ctx, server, lis, closer)
+		close(stopped)
+	}()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	watch, err := api_v1.NewIncrementServiceClient(conn).Watch(context.Background(), &api_v1.WatchRequest{Target: &api_v1.WatchRequest_Prefix{Prefix: ""}})
+	require.NoError(t, err)
+	event, err := watch.Recv()
+	require.NoError(t, err)
+	require.Equal(t, api_v1.WatchEvent_TYPE_SYNCED, event.Type)
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown waited for the open watch")
+	}
+	_, err = watch.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	closer.AssertExpectations(t)
+}
+
+func (m *MockIConfig) GetIdempotencyTTL() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockIConfig) GetRateLimitRules() []interfaces.RateLimitRule {
+	args := m.Called()
+	return args.Get(0).([]interfaces.RateLimitRule)
+}
+
+func (m *MockIConfig) GetSequenceBandwidth() uint64 {
+	args := m.Called()
+	return args.Get(0).(uint64)
+}
+
+func (m *MockIConfig) GetDatabaseVersionsToKeep() int {
+	args := m.Called()
+	return args.Int(0)
+}
+
+func (m *MockIConfig) GetHistoryRetention() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockIConfig) GetDistinctPrecision() uint8 {
+	args := m.Called()
+	return args.Get(0).(uint8)
+}
@@ -0,0 +1,141 @@
+package number
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+)
+
+// historyKeyPrefix prefixes the history entries stored next to the numbers
+const historyKeyPrefix = interfaces.ReservedKeyPrefix + "history/"
+
+// addToHistory stages the history entry of a write, entries expire once the
+// history retention has passed
+// - number: the number after the write
+// - previous: the number before the write
+// Returns an error if the entry can not be staged
+func (t *badgerNumberTxn) addToHistory(number *interfaces.Number, previous *interfaces.Number) error {
+	// reserved numbers are internal bookkeeping, their writes are not audited
+	if strings.HasPrefix(number.ID, interfaces.ReservedKeyPrefix) {
+		return nil
+	}
+	return stageHistory(t.txn, t.historyRetention, interfaces.HistoryEntry{
+		ID:          number.ID,
+		Version:     number.Version,
+		Previous:    previous.Number,
+		Value:       number.Number,
+		Type:        number.Type,
+		BigPrevious: previous.Big,
+		BigValue:    number.Big,
+		At:          number.UpdatedAt,
+		Caller:      number.Source.Caller,
+		RequestID:   number.Source.RequestID,
+	})
+}
+
+// addDeletionToHistory stages the history entry of a delete, it carries the value before the
+// delete and the version following the last write so it sorts after it
+// - txn: the transaction deleting the number
+// - number: the stored number, its Source is credited with the delete
+// Returns an error if the entry can not be staged
+func (r *badgerNumberRepository) addDeletionToHistory(txn *badger.Txn, number *interfaces.Number) error {
+	if strings.HasPrefix(number.ID, interfaces.ReservedKeyPrefix) {
+		return nil
+	}
+	return stageHistory(txn, r.historyRetention, interfaces.HistoryEntry{
+		ID:          number.ID,
+		Version:     number.Version + 1,
+		Previous:    number.Number,
+		Type:        number.Type,
+		BigPrevious: number.Big,
+		Deleted:     true,
+		At:          time.Now().UTC(),
+		Caller:      number.Source.Caller,
+		RequestID:   number.Source.RequestID,
+	})
+}
+
+// stageHistory stages a history entry, its change is derived from the values it holds
+// - txn: the transaction making the write
+// - retention: how long the entry is kept, 0 keeps it forever
+// - record: the entry to stage
+// Returns an error if the entry can not be staged
+func stageHistory(txn *badger.Txn, retention time.Duration, record interfaces.HistoryEntry) error {
+	// only integers held in Number subtract into their change
+	if record.Type == interfaces.NumberTypeUint64 || record.Type == interfaces.NumberTypeInt64 {
+		record.Delta = int64(record.Value - record.Previous)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	entry := badger.NewEntry(historyKey(record.ID, record.At, record.Version), data)
+	if retention > 0 {
+		entry = entry.WithTTL(retention)
+	}
+	return txn.SetEntry(entry)
+}
+
+// History returns the recorded writes of a number in time order, entries are kept
+// after the number is deleted until the history retention drops them
+// - id: the ID of the number
+// - from: only entries at or after this time are returned
+// - to: only entries before this time are returned, zero has no upper limit
+// - after: only entries recorded after this one are returned, nil starts at from
+// - limit: the maximum number of entries to return
+// Returns the entries, or an error if the scan fails
+func (r *badgerNumberRepository) History(id string, from time.Time, to time.Time, after *interfaces.HistoryEntry, limit int) ([]interfaces.HistoryEntry, error) {
+	entries := []interfaces.HistoryEntry{}
+	err := r.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = historyPrefix(id)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		start := string(historyKey(id, from, 0))
+		if after != nil {
+			// the smallest key sorting after the entry is its key followed by a zero byte
+			start = max(start, string(historyKey(id, after.At, after.Version))+"\x00")
+		}
+		end := ""
+		if !to.IsZero() {
+			end = string(historyKey(id, to, 0))
+		}
+		for it.Seek([]byte(start)); it.Valid() && len(entries) < limit; it.Next() {
+			item := it.Item()
+			if end != "" && string(item.Key()) >= end {
+				break
+			}
+			var entry interfaces.HistoryEntry
+			err := item.Value(func(val []byte) error {
+				return json.Unmarshal(val, &entry)
+			})
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// historyPrefix builds the key prefix shared by the history entries of a number, the
+// number ID is terminated by a zero byte so IDs sharing a prefix can not collide
+func historyPrefix(id string) []byte {
+	return []byte(historyKeyPrefix + id + "\x00")
+}
+
+// historyKey builds the key of a history entry, the time and version are big endian
+// so the entries of a number sort by time, and by version within the same instant
+func historyKey(id string, at time.Time, version uint64) []byte {
+	key := binary.BigEndian.AppendUint64(historyPrefix(id), uint64(max(at.UnixNano(), 0)))
+	return binary.BigEndian.AppendUint64(key, version)
+}
@@ -0,0 +1,180 @@
+package number
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBadgerNumberRepository_History(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	set := func(id string, value uint64, caller string) *interfaces.Number {
+		number, err := repo.Update(id, func(number *interfaces.Number) error {
+			number.Number = value
+			number.Source = interfaces.ChangeSource{Caller: caller, RequestID: caller + "-req"}
+			return nil
+		})
+		require.NoError(t, err)
+		return number
+	}
+
+	t.Run("records every write", func(t *testing.T) {
+		set("audited", 5, "alice")
+		set("audited", 3, "bob")
+		set("audited-other", 1, "carol")
+
+		entries, err := repo.History("audited", time.Time{}, time.Time{}, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, interfaces.HistoryEntry{
+			ID: "audited", Version: 1, Previous: 0, Value: 5, Delta: 5, At: entries[0].At, Caller: "alice", RequestID: "alice-req",
+		}, entries[0])
+		assert.Equal(t, uint64(2), entries[1].Version)
+		assert.Equal(t, uint64(5), entries[1].Previous)
+		assert.Equal(t, int64(-2), entries[1].Delta)
+		assert.Equal(t, "bob", entries[1].Caller)
+	})
+
+	t.Run("source is not stored with the number", func(t *testing.T) {
+		found, err := repo.FindByID("audited")
+		require.NoError(t, err)
+		assert.Empty(t, found.Source)
+	})
+
+	t.Run("pages resume after the last entry", func(t *testing.T) {
+		for i := uint64(1); i <= 5; i++ {
+			set("paged", i, "alice")
+		}
+
+		first, err := repo.History("paged", time.Time{}, time.Time{}, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		second, err := repo.History("paged", time.Time{}, time.Time{}, &first[1], 10)
+		require.NoError(t, err)
+		require.Len(t, second, 3)
+		assert.Equal(t, uint64(3), second[0].Version)
+	})
+
+	t.Run("time range filters entries", func(t *testing.T) {
+		before := set("ranged", 1, "alice")
+		time.Sleep(2 * time.Millisecond)
+		middle := set("ranged", 2, "alice")
+		time.Sleep(2 * time.Millisecond)
+		set("ranged", 3, "alice")
+
+		entries, err := repo.History("ranged", middle.UpdatedAt, middle.UpdatedAt.Add(time.Nanosecond), nil, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, uint64(2), entries[0].Value)
+
+		entries, err = repo.History("ranged", before.UpdatedAt.Add(time.Nanosecond), time.Time{}, nil, 10)
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("history outlives the number", func(t *testing.T) {
+		set("deleted", 1, "alice")
+		require.NoError(t, repo.DeleteByID("deleted", interfaces.ChangeSource{Caller: "bob", RequestID: "bob-req"}))
+
+		entries, err := repo.History("deleted", time.Time{}, time.Time{}, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, interfaces.HistoryEntry{
+			ID: "deleted", Version: 2, Previous: 1, Value: 0, Delta: -1, Deleted: true, At: entries[1].At, Caller: "bob", RequestID: "bob-req",
+		}, entries[1])
+	})
+
+	t.Run("checked deletes are recorded", func(t *testing.T) {
+		set("checked", 4, "alice")
+		_, err := repo.DeleteIf("checked", func(number *interfaces.Number) error {
+			number.Source = interfaces.ChangeSource{Caller: "carol"}
+			return nil
+		})
+		require.NoError(t, err)
+
+		entries, err := repo.History("checked", time.Time{}, time.Time{}, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.True(t, entries[1].Deleted)
+		assert.Equal(t, uint64(4), entries[1].Previous)
+		assert.Equal(t, "carol", entries[1].Caller)
+	})
+
+	t.Run("failed checks record nothing", func(t *testing.T) {
+		set("kept", 4, "alice")
+		_, err := repo.DeleteIf("kept", func(number *interfaces.Number) error { return interfaces.ErrVersionMismatch })
+		require.ErrorIs(t, err, interfaces.ErrVersionMismatch)
+
+		entries, err := repo.History("kept", time.Time{}, time.Time{}, nil, 10)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("deleting a missing number records nothing", func(t *testing.T) {
+		require.NoError(t, repo.DeleteByID("never", interfaces.ChangeSource{Caller: "bob"}))
+
+		entries, err := repo.History("never", time.Time{}, time.Time{}, nil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("reserved numbers have no history", func(t *testing.T) {
+		set(interfaces.ReservedKeyPrefix+"fence/lock", 1, "alice")
+
+		entries, err := repo.History(interfaces.ReservedKeyPrefix+"fence/lock", time.Time{}, time.Time{}, nil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("history keys are hidden from listing", func(t *testing.T) {
+		ids, err := repo.ListIDs("", "", 100)
+		require.NoError(t, err)
+		for _, id := range ids {
+			assert.NotContains(t, id, historyKeyPrefix)
+		}
+	})
+}
+
+func TestBadgerNumberRepository_HistoryRetention(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db, WithHistoryRetention(time.Hour))
+	number, err := repo.Update("retained", func(number *interfaces.Number) error {
+		number.Number = 1
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(historyKey("retained", number.UpdatedAt, number.Version))
+		if err != nil {
+			return err
+		}
+		expiresAt := time.Unix(int64(item.ExpiresAt()), 0)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, 2*time.Second)
+		return nil
+	})
+	require.NoError(t, err)
+}
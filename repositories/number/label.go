@@ -0,0 +1,110 @@
+package number
+
+import (
+	"errors"
+	"maps"
+	"strings"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+)
+
+// labelKeyPrefix prefixes the label index entries stored next to the numbers
+const labelKeyPrefix = interfaces.ReservedKeyPrefix + "label/"
+
+// indexLabels keeps the label index of a number in step with a write, entries share the
+// expiry of the number so they disappear together
+// - stored: the number before the write, version 0 when it did not exist
+// - number: the number after the write
+// - deadline: the badger expiry of the number entry, 0 when it never expires
+// Returns an error if an entry can not be staged
+func (t *badgerNumberTxn) indexLabels(stored *interfaces.Number, number *interfaces.Number, deadline uint64) error {
+	if strings.HasPrefix(number.ID, interfaces.ReservedKeyPrefix) {
+		return nil
+	}
+	if maps.Equal(stored.Labels, number.Labels) && stored.ExpiresAt.Equal(expiresAt(deadline)) {
+		return nil
+	}
+	for key, value := range stored.Labels {
+		if current, ok := number.Labels[key]; ok && current == value {
+			continue
+		}
+		if err := t.txn.Delete(labelKey(key, value, number.ID)); err != nil {
+			return err
+		}
+	}
+	for key, value := range number.Labels {
+		entry := badger.NewEntry(labelKey(key, value, number.ID), nil)
+		entry.ExpiresAt = deadline
+		if err := t.txn.SetEntry(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteLabels stages the removal of the label index entries of a number
+// - txn: the transaction deleting the number
+// - id: the ID of the number
+// - labels: the labels of the number
+// Returns an error if a delete can not be staged
+func deleteLabels(txn *badger.Txn, id string, labels map[string]string) error {
+	for key, value := range labels {
+		if err := txn.Delete(labelKey(key, value, id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachLabeled calls fn for every number carrying a label in label value and ID order, the numbers are
+// found through the label index and read from a single consistent snapshot
+// - key: the label key
+// - value: the label value to match, nil matches any value
+// - fn: called for every number with the revision it was read at, returning an error stops the iteration
+// Returns the error from fn or the scan
+func (r *badgerNumberRepository) ForEachLabeled(key string, value *string, fn func(number interfaces.Number, revision uint64) error) error {
+	return r.db.View(func(txn *badger.Txn) error {
+		prefix := labelKeyPrefix + key + "\x00"
+		if value != nil {
+			prefix += *value + "\x00"
+		}
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(prefix)
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			indexed := string(it.Item().Key())
+			// IDs never contain a zero byte, so the ID is whatever follows the last one
+			id := indexed[strings.LastIndexByte(indexed, 0)+1:]
+			item, err := txn.Get([]byte(id))
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			var number interfaces.Number
+			if err := decodeNumber(item, &number); err != nil {
+				return err
+			}
+			// a value holding a zero byte can make a longer value share the prefix
+			current, ok := number.Labels[key]
+			if !ok || (value != nil && current != *value) {
+				continue
+			}
+			if err := fn(number, txn.ReadTs()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// labelKey builds the key of a label index entry, the key and value are terminated by a
+// zero byte so a selector on one label can not match another
+func labelKey(key string, value string, id string) []byte {
+	return []byte(labelKeyPrefix + key + "\x00" + value + "\x00" + id)
+}
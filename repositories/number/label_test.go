@@ -0,0 +1,103 @@
+package number
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBadgerNumberRepository_ForEachLabeled(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBadgerNumberRepository(db)
+	labeled := func(key string, value *string) []string {
+		ids := []string{}
+		err := repo.ForEachLabeled(key, value, func(number interfaces.Number, revision uint64) error {
+			assert.NotZero(t, revision)
+			ids = append(ids, number.ID)
+			return nil
+		})
+		require.NoError(t, err)
+		return ids
+	}
+	value := func(v string) *string { return &v }
+
+	require.NoError(t, repo.Save(interfaces.Number{ID: "a", Labels: map[string]string{"team": "payments", "env": "prod"}}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "b", Labels: map[string]string{"team": "payments"}}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "c", Labels: map[string]string{"team": "search"}}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "d"}))
+	// a value holding a zero byte shares the index prefix of a shorter value
+	require.NoError(t, repo.Save(interfaces.Number{ID: "e", Labels: map[string]string{"team": "payments\x00x"}}))
+
+	assert.Equal(t, []string{"a", "b"}, labeled("team", value("payments")))
+	assert.Equal(t, []string{"c"}, labeled("team", value("search")))
+	assert.Equal(t, []string{"a", "b", "e", "c"}, labeled("team", nil))
+	assert.Equal(t, []string{"a"}, labeled("env", nil))
+	assert.Empty(t, labeled("owner", nil))
+
+	t.Run("relabeling moves the entry", func(t *testing.T) {
+		_, err := repo.Update("b", func(number *interfaces.Number) error {
+			number.Labels = map[string]string{"team": "search"}
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"a"}, labeled("team", value("payments")))
+		assert.Equal(t, []string{"b", "c"}, labeled("team", value("search")))
+	})
+
+	t.Run("value changes keep the entry", func(t *testing.T) {
+		_, err := repo.Update("a", func(number *interfaces.Number) error {
+			number.Number++
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"a"}, labeled("env", value("prod")))
+	})
+
+	t.Run("deletes remove the entries", func(t *testing.T) {
+		require.NoError(t, repo.DeleteByID("a", interfaces.ChangeSource{}))
+		_, err := repo.DeleteIf("c", func(number *interfaces.Number) error { return nil })
+		require.NoError(t, err)
+
+		assert.Empty(t, labeled("env", nil))
+		assert.Equal(t, []string{"b"}, labeled("team", value("search")))
+		count := 0
+		require.NoError(t, db.View(func(txn *badger.Txn) error {
+			opts := badger.DefaultIteratorOptions
+			opts.Prefix = []byte(labelKeyPrefix)
+			it := txn.NewIterator(opts)
+			defer it.Close()
+			for it.Rewind(); it.Valid(); it.Next() {
+				count++
+			}
+			return nil
+		}))
+		assert.Equal(t, 2, count, "only the entries of b and e are left")
+	})
+
+	t.Run("entries expire with the number", func(t *testing.T) {
+		require.NoError(t, repo.Save(interfaces.Number{
+			ID:     "session",
+			Labels: map[string]string{"kind": "session"},
+			Expiry: &interfaces.Expiry{TTL: time.Second},
+		}))
+		assert.Equal(t, []string{"session"}, labeled("kind", nil))
+
+		require.NoError(t, db.View(func(txn *badger.Txn) error {
+			item, err := txn.Get(labelKey("kind", "session", "session"))
+			require.NoError(t, err)
+			number, err := txn.Get([]byte("session"))
+			require.NoError(t, err)
+			assert.Equal(t, number.ExpiresAt(), item.ExpiresAt())
+			return nil
+		}))
+	})
+}
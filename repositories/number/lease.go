@@ -0,0 +1,62 @@
+package number
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+)
+
+// leaseKeyPrefix prefixes the lock leases stored next to the numbers
+const leaseKeyPrefix = interfaces.ReservedKeyPrefix + "lease/"
+
+// GetLease returns the lease stored for a lock as seen by the transaction
+// - name: the name of the lock
+// Returns the lease, or nil if the lock is free or its lease expired
+func (t *badgerNumberTxn) GetLease(name string) (*interfaces.Lease, error) {
+	item, err := t.txn.Get([]byte(leaseKeyPrefix + name))
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var lease interfaces.Lease
+	err = item.Value(func(val []byte) error {
+		return json.Unmarshal(val, &lease)
+	})
+	if err != nil {
+		return nil, err
+	}
+	lease.ExpiresAt = expiresAt(item.ExpiresAt())
+	return &lease, nil
+}
+
+// PutLease stages a lease, badger drops it once the ttl passes
+// - lease: the lease to save, its expiry time is updated in place
+// - ttl: how long the lease lasts at least
+// Returns an error if the write can not be staged
+func (t *badgerNumberTxn) PutLease(lease *interfaces.Lease, ttl time.Duration) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	entry := badger.NewEntry([]byte(leaseKeyPrefix+lease.Name), data)
+	// badger expires entries in whole seconds, round up so a lease never ends early
+	deadline := time.Now().Add(ttl)
+	entry.ExpiresAt = uint64(deadline.Unix())
+	if deadline.Nanosecond() > 0 {
+		entry.ExpiresAt++
+	}
+	lease.ExpiresAt = expiresAt(entry.ExpiresAt)
+	return t.txn.SetEntry(entry)
+}
+
+// DeleteLease stages the removal of the lease of a lock
+// - name: the name of the lock
+// Returns an error if the delete can not be staged
+func (t *badgerNumberTxn) DeleteLease(name string) error {
+	return t.txn.Delete([]byte(leaseKeyPrefix + name))
+}
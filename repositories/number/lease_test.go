@@ -0,0 +1,69 @@
+package number
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBadgerNumberRepository_Lease(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	get := func(name string) *interfaces.Lease {
+		var lease *interfaces.Lease
+		require.NoError(t, repo.Transact(func(txn interfaces.INumberTxn) error {
+			var err error
+			lease, err = txn.GetLease(name)
+			return err
+		}))
+		return lease
+	}
+
+	t.Run("missing lease is nil", func(t *testing.T) {
+		assert.Nil(t, get("cron"))
+	})
+
+	t.Run("stores lease with expiry", func(t *testing.T) {
+		lease := &interfaces.Lease{Name: "cron", Owner: "job-1", Token: 3}
+		before := time.Now().Add(time.Minute)
+		require.NoError(t, repo.Transact(func(txn interfaces.INumberTxn) error {
+			return txn.PutLease(lease, time.Minute)
+		}))
+		assert.False(t, lease.ExpiresAt.Before(before), "lease must not end before its ttl")
+		assert.True(t, lease.ExpiresAt.Before(before.Add(time.Second)))
+		assert.Equal(t, lease, get("cron"))
+	})
+
+	t.Run("leases are not numbers", func(t *testing.T) {
+		ids, err := repo.ListIDs("", "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("deletes lease", func(t *testing.T) {
+		require.NoError(t, repo.Transact(func(txn interfaces.INumberTxn) error {
+			return txn.DeleteLease("cron")
+		}))
+		assert.Nil(t, get("cron"))
+	})
+
+	t.Run("expired lease is nil", func(t *testing.T) {
+		require.NoError(t, repo.Transact(func(txn interfaces.INumberTxn) error {
+			return txn.PutLease(&interfaces.Lease{Name: "old", Token: 1}, -time.Minute)
+		}))
+		assert.Nil(t, get("old"))
+	})
+}
@@ -2,46 +2,70 @@ package number
 
 import (
 	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/repositories/retry"
 	"github.com/dgraph-io/badger/v4"
 )
 
+// reservedKeysEnd is the smallest key sorting after every reserved key
+var reservedKeysEnd = string(rune(interfaces.ReservedKeyPrefix[0] + 1))
+
 type badgerNumberRepository struct {
 	db *badger.DB
+	// historyRetention is how long history entries are kept, 0 keeps them forever
+	historyRetention time.Duration
+}
+
+// Option configures optional badgerNumberRepository behaviour
+type Option func(r *badgerNumberRepository)
+
+// WithHistoryRetention sets how long history entries are kept
+// - retention: time.Duration entry lifetime, 0 keeps entries forever
+func WithHistoryRetention(retention time.Duration) Option {
+	return func(r *badgerNumberRepository) {
+		r.historyRetention = retention
+	}
 }
 
 // NewBadgerNumberRepository creates a new badgerNumberRepository instance
-func NewBadgerNumberRepository(db *badger.DB) interfaces.INumberRepository {
-	return &badgerNumberRepository{db: db}
+// - db: *badger.DB database the numbers are stored in
+// - opts: ...Option optional settings
+func NewBadgerNumberRepository(db *badger.DB, opts ...Option) interfaces.INumberRepository {
+	r := &badgerNumberRepository{db: db}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Save saves a number
-// - number: the number to save
+// - number: the number to save, its version and update time are set by the repository
 // Returns an error if the save operation fails
 func (r *badgerNumberRepository) Save(number interfaces.Number) error {
-	return r.db.Update(func(txn *badger.Txn) error {
-		data, err := json.Marshal(number)
-		if err != nil {
-			return err
-		}
-		return txn.Set([]byte(number.ID), data)
+	_, err := r.Update(number.ID, func(stored *interfaces.Number) error {
+		*stored = number
+		return nil
 	})
+	return err
 }
 
 // FindByID finds a number by its ID
 // - id: the ID of the number to find
-// Returns the number if found, otherwise returns an error
+// Returns the number if found, ErrNotFound if it does not exist, otherwise returns an error
 func (r *badgerNumberRepository) FindByID(id string) (*interfaces.Number, error) {
 	var number interfaces.Number
 	err := r.db.View(func(txn *badger.Txn) error {
 		item, err := txn.Get([]byte(id))
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return interfaces.ErrNotFound
+		}
 		if err != nil {
 			return err
 		}
-		return item.Value(func(val []byte) error {
-			return json.Unmarshal(val, &number)
-		})
+		return decodeNumber(item, &number)
 	})
 	if err != nil {
 		return nil, err
@@ -49,11 +73,248 @@ func (r *badgerNumberRepository) FindByID(id string) (*interfaces.Number, error)
 	return &number, nil
 }
 
-// DeleteByID deletes a number by its ID
+// Update atomically reads, modifies and saves a number inside a single transaction,
+// the version is incremented and the update time set after fn returns
+// - id: the ID of the number to update
+// - fn: called with the stored number, or a zero value number when none exists yet
+// Returns the saved number, the error from fn, or a ConflictError once retries run out
+func (r *badgerNumberRepository) Update(id string, fn func(number *interfaces.Number) error) (*interfaces.Number, error) {
+	var number *interfaces.Number
+	err := r.Transact(func(txn interfaces.INumberTxn) error {
+		var err error
+		number, err = txn.Get(id)
+		if err != nil {
+			return err
+		}
+		if err := fn(number); err != nil {
+			return err
+		}
+		number.ID = id
+		return txn.Put(number)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+// List returns numbers in ID order
+// - prefix: only numbers whose ID starts with prefix are returned
+// - after: only numbers whose ID sorts after this one are returned, empty starts at the beginning
+// - limit: the maximum number of numbers to return
+// Returns the matching numbers, or an error if the scan fails
+func (r *badgerNumberRepository) List(prefix string, after string, limit int) ([]interfaces.Number, error) {
+	numbers := []interfaces.Number{}
+	err := r.scan(prefix, after, limit, true, func(item *badger.Item) error {
+		var number interfaces.Number
+		if err := decodeNumber(item, &number); err != nil {
+			return err
+		}
+		numbers = append(numbers, number)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return numbers, nil
+}
+
+// ForEach calls fn for every number under a prefix in ID order from a single consistent read
+// - prefix: only numbers whose ID starts with prefix are visited
+// - fn: called for every number with the revision it was read at, returning an error stops the iteration
+// Returns the error from fn or the scan
+func (r *badgerNumberRepository) ForEach(prefix string, fn func(number interfaces.Number, revision uint64) error) error {
+	return r.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(prefix)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		start := prefix
+		if prefix == "" {
+			// reserved keys sort before every valid name, skip them in one seek
+			start = reservedKeysEnd
+		}
+		for it.Seek([]byte(start)); it.Valid(); it.Next() {
+			var number interfaces.Number
+			if err := decodeNumber(it.Item(), &number); err != nil {
+				return err
+			}
+			if err := fn(number, txn.ReadTs()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListIDs returns number IDs in order using a key-only iteration
+// - prefix: only IDs that start with prefix are returned
+// - after: only IDs that sort after this one are returned, empty starts at the beginning
+// - limit: the maximum number of IDs to return
+// Returns the matching IDs, or an error if the scan fails
+func (r *badgerNumberRepository) ListIDs(prefix string, after string, limit int) ([]string, error) {
+	ids := []string{}
+	err := r.scan(prefix, after, limit, false, func(item *badger.Item) error {
+		ids = append(ids, string(item.Key()))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// scan iterates the keys with a prefix in order inside a read transaction
+// - prefix: key prefix to iterate
+// - after: iteration starts at the first key sorting after this one
+// - limit: the maximum number of items visited
+// - withValues: whether values are prefetched, false for key-only iteration
+// - fn: called for each item
+// Returns the first error from the iteration or fn
+func (r *badgerNumberRepository) scan(prefix string, after string, limit int, withValues bool, fn func(item *badger.Item) error) error {
+	return r.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(prefix)
+		opts.PrefetchValues = withValues
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		start := prefix
+		if prefix == "" {
+			// reserved keys sort before every valid name, skip them in one seek
+			start = reservedKeysEnd
+		}
+		if after != "" {
+			// the smallest key sorting after "after" is "after" followed by a zero byte
+			start = max(start, after+"\x00")
+		}
+		visited := 0
+		for it.Seek([]byte(start)); it.Valid() && visited < limit; it.Next() {
+			if err := fn(it.Item()); err != nil {
+				return err
+			}
+			visited++
+		}
+		return nil
+	})
+}
+
+// DeleteByID deletes a number, its window buckets and its label and rank index entries by its ID,
+// a history entry for the delete is staged in the same transaction when the number exists
 // - id: the ID of the number to delete
+// - source: who is deleting the number, recorded in the history entry
 // Returns an error if the delete operation fails
-func (r *badgerNumberRepository) DeleteByID(id string) error {
-	return r.db.Update(func(txn *badger.Txn) error {
-		return txn.Delete([]byte(id))
+func (r *badgerNumberRepository) DeleteByID(id string, source interfaces.ChangeSource) error {
+	return retry.OnConflict(id, func() error {
+		return r.db.Update(func(txn *badger.Txn) error {
+			var number interfaces.Number
+			item, err := txn.Get([]byte(id))
+			if err != nil && !errors.Is(err, badger.ErrKeyNotFound) {
+				return err
+			}
+			if err == nil {
+				if err := decodeNumber(item, &number); err != nil {
+					return err
+				}
+				number.Source = source
+				if err := r.addDeletionToHistory(txn, &number); err != nil {
+					return err
+				}
+			}
+			if err := deleteLabels(txn, id, number.Labels); err != nil {
+				return err
+			}
+			if err := deleteRanks(txn, &number); err != nil {
+				return err
+			}
+			if err := deleteWindows(txn, id); err != nil {
+				return err
+			}
+			return txn.Delete([]byte(id))
+		})
 	})
 }
+
+// DeleteIf atomically checks and deletes a number, its window buckets and its label and rank
+// index entries inside a single transaction, along with a history entry for the delete
+// - id: the ID of the number to delete
+// - check: called with the stored number, the number is only deleted when it returns nil, the
+// number.Source it sets is credited with the delete
+// Returns the deleted number, ErrNotFound if it does not exist, or the error from check
+func (r *badgerNumberRepository) DeleteIf(id string, check func(number *interfaces.Number) error) (*interfaces.Number, error) {
+	var number interfaces.Number
+	err := retry.OnConflict(id, func() error {
+		return r.db.Update(func(txn *badger.Txn) error {
+			number = interfaces.Number{}
+			item, err := txn.Get([]byte(id))
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return interfaces.ErrNotFound
+			}
+			if err != nil {
+				return err
+			}
+			if err := decodeNumber(item, &number); err != nil {
+				return err
+			}
+			if err := check(&number); err != nil {
+				return err
+			}
+			if err := r.addDeletionToHistory(txn, &number); err != nil {
+				return err
+			}
+			if err := deleteLabels(txn, id, number.Labels); err != nil {
+				return err
+			}
+			if err := deleteRanks(txn, &number); err != nil {
+				return err
+			}
+			if err := deleteWindows(txn, id); err != nil {
+				return err
+			}
+			return txn.Delete([]byte(id))
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &number, nil
+}
+
+// decodeNumber reads a stored number from a badger item
+// - item: the item holding the number
+// - number: filled with the stored number and the expiry of the item
+// Returns an error if the value can not be read or decoded
+func decodeNumber(item *badger.Item, number *interfaces.Number) error {
+	err := item.Value(func(val []byte) error {
+		return unmarshalNumber(val, number)
+	})
+	if err != nil {
+		return err
+	}
+	number.ExpiresAt = expiresAt(item.ExpiresAt())
+	return nil
+}
+
+// unmarshalNumber decodes the stored value of a number, numbers saved before versions were
+// tracked have no version and read as version 1 so they are never mistaken for missing ones
+// - val: the stored value
+// - number: filled with the stored number
+// Returns an error if the value can not be decoded
+func unmarshalNumber(val []byte, number *interfaces.Number) error {
+	if err := json.Unmarshal(val, number); err != nil {
+		return err
+	}
+	number.Version = max(number.Version, 1)
+	return nil
+}
+
+// expiresAt converts a badger expiry timestamp into a time
+// - ts: unix seconds of the expiry, 0 for entries that never expire
+// Returns the expiry time, zero for entries that never expire
+func expiresAt(ts uint64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(ts), 0).UTC()
+}
@@ -2,7 +2,11 @@ package number
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/bryopsida/go-grpc-server-template/interfaces"
 	"github.com/dgraph-io/badger/v4"
@@ -57,7 +61,18 @@ func TestBadgerNumberRepository_Save(t *testing.T) {
 		})
 	})
 	assert.NoError(t, err)
-	assert.Equal(t, number, savedNumber)
+	assert.Equal(t, number.ID, savedNumber.ID)
+	assert.Equal(t, number.Number, savedNumber.Number)
+	assert.Equal(t, uint64(1), savedNumber.Version)
+	assert.False(t, savedNumber.UpdatedAt.IsZero())
+
+	// Saving again bumps the version
+	err = repo.Save(interfaces.Number{ID: "1", Number: 43})
+	assert.NoError(t, err)
+	found, err := repo.FindByID("1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(43), found.Number)
+	assert.Equal(t, uint64(2), found.Version)
 }
 
 func TestBadgerNumberRepository_FindByID(t *testing.T) {
@@ -84,7 +99,14 @@ func TestBadgerNumberRepository_FindByID(t *testing.T) {
 	foundNumber, err := repo.FindByID(number.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, foundNumber)
-	assert.Equal(t, number, *foundNumber)
+	assert.Equal(t, number.ID, foundNumber.ID)
+	assert.Equal(t, number.Number, foundNumber.Number)
+	assert.Equal(t, uint64(1), foundNumber.Version)
+
+	// Missing numbers return ErrNotFound
+	missing, err := repo.FindByID("missing")
+	assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	assert.Nil(t, missing)
 }
 
 func TestBadgerNumberRepository_DeleteByID(t *testing.T) {
@@ -119,10 +141,10 @@ func TestBadgerNumberRepository_DeleteByID(t *testing.T) {
 		})
 	})
 	require.NoError(t, err)
-	assert.Equal(t, number, savedNumber)
+	assert.Equal(t, number.Number, savedNumber.Number)
 
 	// Delete the number by ID
-	err = repo.DeleteByID(number.ID)
+	err = repo.DeleteByID(number.ID, interfaces.ChangeSource{})
 	assert.NoError(t, err)
 
 	// Verify the number was deleted
@@ -134,4 +156,636 @@ func TestBadgerNumberRepository_DeleteByID(t *testing.T) {
 		return err
 	})
 	assert.NoError(t, err)
+
+	t.Run("deletes racing updates are retried", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 25; j++ {
+					_, _ = repo.Update("raced", func(number *interfaces.Number) error {
+						number.Number++
+						return nil
+					})
+				}
+			}()
+		}
+		for j := 0; j < 25; j++ {
+			assert.NoError(t, repo.DeleteByID("raced", interfaces.ChangeSource{}))
+		}
+		wg.Wait()
+	})
+}
+
+func TestBadgerNumberRepository_Update(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+
+	increment := func(number *interfaces.Number) error {
+		number.Number++
+		return nil
+	}
+
+	t.Run("creates missing number", func(t *testing.T) {
+		updated, err := repo.Update("new", increment)
+		assert.NoError(t, err)
+		assert.Equal(t, "new", updated.ID)
+		assert.Equal(t, uint64(1), updated.Number)
+		assert.Equal(t, uint64(1), updated.Version)
+	})
+
+	t.Run("updates existing number", func(t *testing.T) {
+		require.NoError(t, repo.Save(interfaces.Number{ID: "existing", Number: 41}))
+
+		updated, err := repo.Update("existing", increment)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(42), updated.Number)
+
+		found, err := repo.FindByID("existing")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(42), found.Number)
+		assert.Equal(t, uint64(2), found.Version)
+		assert.Equal(t, updated.UpdatedAt, found.UpdatedAt)
+	})
+
+	t.Run("fn error aborts the update", func(t *testing.T) {
+		require.NoError(t, repo.Save(interfaces.Number{ID: "aborted", Number: 7}))
+		fnErr := errors.New("rejected")
+
+		updated, err := repo.Update("aborted", func(number *interfaces.Number) error {
+			number.Number = 100
+			return fnErr
+		})
+		assert.ErrorIs(t, err, fnErr)
+		assert.Nil(t, updated)
+
+		found, err := repo.FindByID("aborted")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), found.Number)
+	})
+
+	t.Run("concurrent updates are not lost", func(t *testing.T) {
+		const workers = 8
+		const perWorker = 25
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		succeeded := 0
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < perWorker; j++ {
+					_, err := repo.Update("contended", increment)
+					if err != nil {
+						assert.ErrorIs(t, err, interfaces.ErrConflict)
+						continue
+					}
+					mu.Lock()
+					succeeded++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		found, err := repo.FindByID("contended")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(succeeded), found.Number)
+	})
+}
+
+func TestBadgerNumberRepository_DeleteIf(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	require.NoError(t, repo.Save(interfaces.Number{ID: "1", Number: 42}))
+
+	t.Run("check failure keeps the number", func(t *testing.T) {
+		checkErr := errors.New("rejected")
+		deleted, err := repo.DeleteIf("1", func(number *interfaces.Number) error {
+			return checkErr
+		})
+		assert.ErrorIs(t, err, checkErr)
+		assert.Nil(t, deleted)
+
+		_, err = repo.FindByID("1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("deletes when check passes", func(t *testing.T) {
+		deleted, err := repo.DeleteIf("1", func(number *interfaces.Number) error {
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(42), deleted.Number)
+
+		_, err = repo.FindByID("1")
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	})
+
+	t.Run("missing number is not found", func(t *testing.T) {
+		deleted, err := repo.DeleteIf("1", func(number *interfaces.Number) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+		assert.Nil(t, deleted)
+	})
+}
+
+func TestBadgerNumberRepository_List(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	for i, id := range []string{"a/1", "a/2", "a/3", "b/1", "c"} {
+		require.NoError(t, repo.Save(interfaces.Number{ID: id, Number: uint64(i)}))
+	}
+	// internal records are never listed
+	require.NoError(t, db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(interfaces.ReservedKeyPrefix+"internal"), []byte("{}"))
+	}))
+
+	ids := func(numbers []interfaces.Number) []string {
+		result := []string{}
+		for _, number := range numbers {
+			result = append(result, number.ID)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		prefix   string
+		after    string
+		limit    int
+		expected []string
+	}{
+		{name: "All", limit: 10, expected: []string{"a/1", "a/2", "a/3", "b/1", "c"}},
+		{name: "Limit", limit: 2, expected: []string{"a/1", "a/2"}},
+		{name: "Prefix", prefix: "a/", limit: 10, expected: []string{"a/1", "a/2", "a/3"}},
+		{name: "After", after: "a/2", limit: 10, expected: []string{"a/3", "b/1", "c"}},
+		{name: "PrefixAndAfter", prefix: "a/", after: "a/1", limit: 1, expected: []string{"a/2"}},
+		{name: "AfterBeforePrefix", prefix: "b/", after: "a/3", limit: 10, expected: []string{"b/1"}},
+		{name: "AfterMissingKey", after: "a/25", limit: 10, expected: []string{"a/3", "b/1", "c"}},
+		{name: "NoMatches", prefix: "d", limit: 10, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			numbers, err := repo.List(tt.prefix, tt.after, tt.limit)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ids(numbers))
+
+			keys, err := repo.ListIDs(tt.prefix, tt.after, tt.limit)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, keys)
+		})
+	}
+
+	numbers, err := repo.List("c", "", 1)
+	require.NoError(t, err)
+	require.Len(t, numbers, 1)
+	assert.Equal(t, uint64(4), numbers[0].Number)
+}
+
+func TestBadgerNumberRepository_ForEach(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBadgerNumberRepository(db)
+	for i, id := range []string{"a/1", "a/2", "b/1"} {
+		require.NoError(t, repo.Save(interfaces.Number{ID: id, Number: uint64(i)}))
+	}
+	require.NoError(t, db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(interfaces.ReservedKeyPrefix+"internal"), []byte("{}"))
+	}))
+
+	visit := func(prefix string) ([]string, []uint64, error) {
+		ids := []string{}
+		revisions := []uint64{}
+		err := repo.ForEach(prefix, func(number interfaces.Number, revision uint64) error {
+			ids = append(ids, number.ID)
+			revisions = append(revisions, revision)
+			return nil
+		})
+		return ids, revisions, err
+	}
+
+	ids, revisions, err := visit("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a/1", "a/2", "b/1"}, ids)
+	assert.Equal(t, revisions[0], revisions[2], "every number is read at the same revision")
+	assert.NotZero(t, revisions[0])
+
+	ids, _, err = visit("a/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a/1", "a/2"}, ids)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = repo.ForEach("", func(number interfaces.Number, revision uint64) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestBadgerNumberRepository_Transact(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	require.NoError(t, repo.Save(interfaces.Number{ID: "from", Number: 10}))
+
+	move := func(amount uint64) func(txn interfaces.INumberTxn) error {
+		return func(txn interfaces.INumberTxn) error {
+			from, err := txn.Get("from")
+			if err != nil {
+				return err
+			}
+			to, err := txn.Get("to")
+			if err != nil {
+				return err
+			}
+			to.Number += amount
+			if err := txn.Put(to); err != nil {
+				return err
+			}
+			if from.Number < amount {
+				return interfaces.ErrOutOfRange
+			}
+			from.Number -= amount
+			return txn.Put(from)
+		}
+	}
+
+	t.Run("commits every write", func(t *testing.T) {
+		require.NoError(t, repo.Transact(move(4)))
+
+		from, err := repo.FindByID("from")
+		require.NoError(t, err)
+		to, err := repo.FindByID("to")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(6), from.Number)
+		assert.Equal(t, uint64(2), from.Version)
+		assert.Equal(t, uint64(4), to.Number)
+		assert.Equal(t, uint64(1), to.Version)
+	})
+
+	t.Run("error discards every write", func(t *testing.T) {
+		err := repo.Transact(move(100))
+		assert.ErrorIs(t, err, interfaces.ErrOutOfRange)
+
+		to, err := repo.FindByID("to")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), to.Number)
+	})
+
+	t.Run("reads see earlier writes", func(t *testing.T) {
+		err := repo.Transact(func(txn interfaces.INumberTxn) error {
+			number, err := txn.Get("twice")
+			if err != nil {
+				return err
+			}
+			number.Number = 1
+			if err := txn.Put(number); err != nil {
+				return err
+			}
+			again, err := txn.Get("twice")
+			if err != nil {
+				return err
+			}
+			assert.Equal(t, uint64(1), again.Number)
+			assert.Equal(t, uint64(1), again.Version)
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("versions set past the next one are kept", func(t *testing.T) {
+		put := func(version uint64) {
+			require.NoError(t, repo.Transact(func(txn interfaces.INumberTxn) error {
+				number, err := txn.Get("restored")
+				if err != nil {
+					return err
+				}
+				number.Version = version
+				return txn.Put(number)
+			}))
+		}
+
+		put(7)
+		restored, err := repo.FindByID("restored")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), restored.Version)
+
+		put(3)
+		restored, err = repo.FindByID("restored")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8), restored.Version, "a version never goes back")
+	})
+}
+
+func TestBadgerNumberRepository_Idempotency(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	record := interfaces.IdempotencyRecord{Fingerprint: "add:5", Value: 5}
+
+	err = repo.Transact(func(txn interfaces.INumberTxn) error {
+		missing, err := txn.GetIdempotency("a", "key")
+		assert.NoError(t, err)
+		assert.Nil(t, missing)
+		if err := txn.PutIdempotency("a", "key", record, 0); err != nil {
+			return err
+		}
+		return txn.PutIdempotency("a", "short-lived", record, time.Second)
+	})
+	require.NoError(t, err)
+
+	get := func(id, key string) *interfaces.IdempotencyRecord {
+		var found *interfaces.IdempotencyRecord
+		require.NoError(t, repo.Transact(func(txn interfaces.INumberTxn) error {
+			var err error
+			found, err = txn.GetIdempotency(id, key)
+			return err
+		}))
+		return found
+	}
+
+	assert.Equal(t, &record, get("a", "key"))
+	assert.Nil(t, get("ab", "key"), "records are scoped to their number")
+	assert.Nil(t, get("a", "other"))
+
+	// records are not numbers
+	ids, err := repo.ListIDs("", "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	// badger drops records once their ttl passes
+	assert.Eventually(t, func() bool {
+		return get("a", "short-lived") == nil
+	}, 3*time.Second, 100*time.Millisecond)
+	assert.NotNil(t, get("a", "key"))
+}
+
+func TestBadgerNumberRepository_Expiry(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+
+	t.Run("never expires without expiry", func(t *testing.T) {
+		number, err := repo.Update("forever", func(number *interfaces.Number) error { return nil })
+		require.NoError(t, err)
+		assert.True(t, number.ExpiresAt.IsZero())
+	})
+
+	t.Run("first write starts the deadline", func(t *testing.T) {
+		before := time.Now().Add(time.Hour).Truncate(time.Second)
+		number, err := repo.Update("fixed", func(number *interfaces.Number) error {
+			number.Expiry = &interfaces.Expiry{TTL: time.Hour}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.False(t, number.ExpiresAt.Before(before))
+
+		found, err := repo.FindByID("fixed")
+		require.NoError(t, err)
+		assert.Equal(t, number.ExpiresAt, found.ExpiresAt)
+		assert.Equal(t, &interfaces.Expiry{TTL: time.Hour}, found.Expiry)
+	})
+
+	t.Run("fixed expiry keeps the deadline", func(t *testing.T) {
+		deadline := time.Now().Add(time.Minute).Truncate(time.Second).UTC()
+		require.NoError(t, repo.Save(interfaces.Number{ID: "kept", Expiry: &interfaces.Expiry{TTL: time.Hour}, ExpiresAt: deadline}))
+
+		number, err := repo.Update("kept", func(number *interfaces.Number) error {
+			number.Number++
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, deadline, number.ExpiresAt)
+	})
+
+	t.Run("sliding expiry restarts the deadline", func(t *testing.T) {
+		deadline := time.Now().Add(time.Minute).Truncate(time.Second).UTC()
+		require.NoError(t, repo.Save(interfaces.Number{ID: "sliding", Expiry: &interfaces.Expiry{TTL: time.Hour, Sliding: true}, ExpiresAt: deadline}))
+
+		number, err := repo.Update("sliding", func(number *interfaces.Number) error {
+			number.Number++
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, number.ExpiresAt.After(deadline))
+	})
+
+	t.Run("expired numbers are absent", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		require.NoError(t, repo.Save(interfaces.Number{ID: "gone", Number: 4, Expiry: &interfaces.Expiry{TTL: time.Hour}, ExpiresAt: past}))
+
+		_, err := repo.FindByID("gone")
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+		ids, err := repo.ListIDs("gone", "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+		number, err := repo.Update("gone", func(number *interfaces.Number) error { return nil })
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), number.Number)
+		assert.Equal(t, uint64(1), number.Version)
+	})
+}
+
+func TestBadgerNumberRepository_Metadata(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+
+	t.Run("creation time is kept across updates", func(t *testing.T) {
+		created, err := repo.Update("labelled", func(number *interfaces.Number) error {
+			number.Labels = map[string]string{"team": "payments"}
+			number.Owner = "alice"
+			return nil
+		})
+		require.NoError(t, err)
+		assert.False(t, created.CreatedAt.IsZero())
+		assert.Equal(t, created.UpdatedAt, created.CreatedAt)
+
+		time.Sleep(2 * time.Millisecond)
+		updated, err := repo.Update("labelled", func(number *interfaces.Number) error {
+			number.Number++
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, created.CreatedAt.Equal(updated.CreatedAt))
+		assert.True(t, updated.UpdatedAt.After(updated.CreatedAt))
+
+		found, err := repo.FindByID("labelled")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"team": "payments"}, found.Labels)
+		assert.Equal(t, "alice", found.Owner)
+		assert.True(t, created.CreatedAt.Equal(found.CreatedAt))
+	})
+
+	t.Run("numbers saved by the first releases still decode", func(t *testing.T) {
+		// the shape the first releases saved, before versions, times and metadata were tracked
+		legacy := []byte(`{"ID":"legacy","Number":5}`)
+		require.NoError(t, db.Update(func(txn *badger.Txn) error {
+			return txn.Set([]byte("legacy"), legacy)
+		}))
+
+		found, err := repo.FindByID("legacy")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), found.Number)
+		assert.Equal(t, uint64(1), found.Version, "an existing number is never at version 0")
+		assert.Nil(t, found.Labels)
+		assert.Empty(t, found.Owner)
+		assert.True(t, found.CreatedAt.IsZero())
+
+		updated, err := repo.Update("legacy", func(number *interfaces.Number) error {
+			assert.Equal(t, uint64(1), number.Version)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), updated.Version)
+		assert.True(t, updated.CreatedAt.IsZero(), "the creation time of the number is not known")
+	})
+}
+
+func TestBadgerNumberRepository_Types(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBadgerNumberRepository(db)
+	negative := int64(-7)
+	tests := []struct {
+		name       string
+		numberType interfaces.NumberType
+		value      uint64
+		delta      int64
+	}{
+		{name: "Uint64", numberType: interfaces.NumberTypeUint64, value: math.MaxUint64, delta: -1},
+		{name: "Int64", numberType: interfaces.NumberTypeInt64, value: uint64(negative), delta: -7},
+		{name: "Float64", numberType: interfaces.NumberTypeFloat64, value: math.Float64bits(0.1 + 1e-17)},
+		{name: "SmallestFloat64", numberType: interfaces.NumberTypeFloat64, value: math.Float64bits(math.SmallestNonzeroFloat64)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, repo.Save(interfaces.Number{ID: tt.name, Number: tt.value, Type: tt.numberType}))
+
+			found, err := repo.FindByID(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.numberType, found.Type)
+			assert.Equal(t, tt.value, found.Number)
+
+			entries, err := repo.History(tt.name, time.Time{}, time.Time{}, nil, 10)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.numberType, entries[0].Type)
+			assert.Equal(t, tt.value, entries[0].Value)
+			assert.Equal(t, tt.delta, entries[0].Delta, "float64 changes have no delta")
+		})
+	}
+}
+
+func TestBadgerNumberRepository_BigInt(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBadgerNumberRepository(db)
+	require.NoError(t, repo.Save(interfaces.Number{ID: "bytes", Big: "18446744073709551616", Type: interfaces.NumberTypeBigInt}))
+	_, err = repo.Update("bytes", func(number *interfaces.Number) error {
+		number.Big = "36893488147419103232"
+		return nil
+	})
+	require.NoError(t, err)
+
+	found, err := repo.FindByID("bytes")
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.NumberTypeBigInt, found.Type)
+	assert.Equal(t, "36893488147419103232", found.Big)
+	assert.Zero(t, found.Number)
+
+	entries, err := repo.History("bytes", time.Time{}, time.Time{}, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "18446744073709551616", entries[1].BigPrevious)
+	assert.Equal(t, "36893488147419103232", entries[1].BigValue)
+	assert.Zero(t, entries[1].Delta, "big integer changes have no delta")
+}
+
+func TestBadgerNumberRepository_Sketch(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBadgerNumberRepository(db)
+	sketch := []byte{4, 0, 3, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0}
+	require.NoError(t, repo.Save(interfaces.Number{ID: "visitors", Number: 3, Sketch: sketch, Type: interfaces.NumberTypeDistinct}))
+
+	found, err := repo.FindByID("visitors")
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.NumberTypeDistinct, found.Type)
+	assert.Equal(t, sketch, found.Sketch)
+	assert.Equal(t, uint64(3), found.Number)
 }
@@ -0,0 +1,332 @@
+package number
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/repositories/retry"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/dgraph-io/badger/v4/pb"
+)
+
+const (
+	// rankKeyPrefix prefixes the value ordered index entries stored next to the numbers
+	rankKeyPrefix = interfaces.ReservedKeyPrefix + "rank/"
+	// rankMigrationKey marks a store whose rank index holds the numbers saved before it existed
+	rankMigrationKey = interfaces.ReservedKeyPrefix + "migration/rank"
+	// rankMigrationBatch is how many numbers are indexed per transaction by Migrate
+	rankMigrationBatch = 1000
+	// maxRankScan caps how many higher entries Rank counts, the cost of a rank grows with it
+	maxRankScan = 10000
+)
+
+// ranked reports whether a number has entries in the rank index, only uint64 numbers are ranked
+func ranked(number *interfaces.Number) bool {
+	return number.Version != 0 && number.Type == interfaces.NumberTypeUint64 &&
+		!strings.HasPrefix(number.ID, interfaces.ReservedKeyPrefix)
+}
+
+// leaderboards lists the leaderboards a number is ranked on, the empty prefix and every
+// prefix of its ID ending in a slash
+func leaderboards(id string) []string {
+	boards := []string{""}
+	for i := range id {
+		if id[i] == '/' {
+			boards = append(boards, id[:i+1])
+		}
+	}
+	return boards
+}
+
+// indexRank keeps the rank index of a number in step with a write, entries share the
+// expiry of the number so they disappear together
+// - stored: the number before the write, version 0 when it did not exist
+// - number: the number after the write, its version already set
+// - deadline: the badger expiry of the number entry, 0 when it never expires
+// Returns an error if an entry can not be staged
+func (t *badgerNumberTxn) indexRank(stored *interfaces.Number, number *interfaces.Number, deadline uint64) error {
+	wasRanked, isRanked := ranked(stored), ranked(number)
+	unchanged := wasRanked && isRanked && stored.Number == number.Number
+	if unchanged && stored.ExpiresAt.Equal(expiresAt(deadline)) {
+		return nil
+	}
+	if wasRanked && !unchanged {
+		if err := deleteRanks(t.txn, stored); err != nil {
+			return err
+		}
+	}
+	if !isRanked {
+		return nil
+	}
+	for _, board := range leaderboards(number.ID) {
+		entry := badger.NewEntry(rankKey(board, number.Number, number.ID), nil)
+		entry.ExpiresAt = deadline
+		if err := t.txn.SetEntry(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteRanks stages the removal of the rank index entries of a number
+// - txn: the transaction changing the number
+// - number: the number as stored, nothing is removed when it is not ranked
+// Returns an error if a delete can not be staged
+func deleteRanks(txn *badger.Txn, number *interfaces.Number) error {
+	if !ranked(number) {
+		return nil
+	}
+	for _, board := range leaderboards(number.ID) {
+		if err := txn.Delete(rankKey(board, number.Number, number.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Migrate adds the numbers saved before the rank index existed to it, a batch of numbers is
+// read and indexed per transaction so numbers written meanwhile are never indexed at a stale
+// value, once every number is indexed a marker is stored and later calls return at once
+// Returns an error if a batch can not be indexed, the next call starts over
+func (r *badgerNumberRepository) Migrate() error {
+	err := r.db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get([]byte(rankMigrationKey))
+		return err
+	})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, badger.ErrKeyNotFound) {
+		return err
+	}
+	after := ""
+	for {
+		var last string
+		err := retry.OnConflict(rankMigrationKey, func() error {
+			return r.db.Update(func(txn *badger.Txn) error {
+				var err error
+				last, err = indexRankBatch(txn, after)
+				return err
+			})
+		})
+		if err != nil {
+			return err
+		}
+		if last == "" {
+			break
+		}
+		after = last
+	}
+	return r.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(rankMigrationKey), []byte{1})
+	})
+}
+
+// indexRankBatch stages the rank index entries of the next batch of numbers
+// - txn: the transaction to read and write in
+// - after: the batch starts at the first number sorting after this ID, empty starts at the beginning
+// Returns the ID of the last number of the batch, empty when there were none left
+func indexRankBatch(txn *badger.Txn, after string) (string, error) {
+	it := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer it.Close()
+
+	// reserved keys sort before every valid name, skip them in one seek
+	start := reservedKeysEnd
+	if after != "" {
+		start = after + "\x00"
+	}
+	last := ""
+	visited := 0
+	for it.Seek([]byte(start)); it.Valid() && visited < rankMigrationBatch; it.Next() {
+		item := it.Item()
+		number := interfaces.Number{ID: string(item.KeyCopy(nil))}
+		if err := decodeNumber(item, &number); err != nil {
+			return "", err
+		}
+		last = number.ID
+		visited++
+		if !ranked(&number) {
+			continue
+		}
+		for _, board := range leaderboards(number.ID) {
+			entry := badger.NewEntry(rankKey(board, number.Number, number.ID), nil)
+			entry.ExpiresAt = item.ExpiresAt()
+			if err := txn.SetEntry(entry); err != nil {
+				return "", err
+			}
+		}
+	}
+	return last, nil
+}
+
+// TopK returns the highest numbers of a leaderboard by reading the first entries of the rank index
+// - prefix: the leaderboard, empty or ending in a slash
+// - k: the maximum number of entries to return
+// Returns the entries in descending value order with ties in ID order, and the revision they were read at
+func (r *badgerNumberRepository) TopK(prefix string, k int) ([]interfaces.RankEntry, uint64, error) {
+	entries := []interfaces.RankEntry{}
+	var revision uint64
+	err := r.db.View(func(txn *badger.Txn) error {
+		revision = txn.ReadTs()
+		board := rankBoardPrefix(prefix)
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = board
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid() && len(entries) < k; it.Next() {
+			entry := parseRankKey(it.Item().Key(), len(board))
+			entry.Rank = uint64(len(entries)) + 1
+			if last := len(entries) - 1; last >= 0 && entries[last].Value == entry.Value {
+				entry.Rank = entries[last].Rank
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	return entries, revision, err
+}
+
+// Rank returns the position of a number on a leaderboard, found by counting the index
+// entries of higher numbers, at most maxRankScan of them are counted
+// - id: the ID of the number
+// - prefix: the leaderboard, empty or a prefix of the ID ending in a slash
+// Returns the entry of the number, numbers with equal values share a rank, and the revision
+// it was read at, ErrNotFound if the number does not exist, ErrTypeMismatch if it is not
+// a uint64 number, or ErrOutOfRange if more than maxRankScan numbers rank above it
+func (r *badgerNumberRepository) Rank(id string, prefix string) (*interfaces.RankEntry, uint64, error) {
+	var entry *interfaces.RankEntry
+	var revision uint64
+	err := r.db.View(func(txn *badger.Txn) error {
+		revision = txn.ReadTs()
+		item, err := txn.Get([]byte(id))
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return fmt.Errorf("%w: %s", interfaces.ErrNotFound, id)
+		}
+		if err != nil {
+			return err
+		}
+		var number interfaces.Number
+		if err := decodeNumber(item, &number); err != nil {
+			return err
+		}
+		if number.Type != interfaces.NumberTypeUint64 {
+			return fmt.Errorf("%w: %s is not a uint64 number", interfaces.ErrTypeMismatch, id)
+		}
+
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = rankBoardPrefix(prefix)
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		// entries of equal values sort after the value with an empty ID
+		own := rankKey(prefix, number.Number, "")
+		higher := uint64(0)
+		for it.Rewind(); it.Valid() && bytes.Compare(it.Item().Key(), own) < 0; it.Next() {
+			if higher == maxRankScan {
+				return fmt.Errorf("%w: %s ranks below the first %d entries of the leaderboard", interfaces.ErrOutOfRange, id, maxRankScan)
+			}
+			higher++
+		}
+		entry = &interfaces.RankEntry{ID: id, Value: number.Number, Rank: higher + 1}
+		return nil
+	})
+	return entry, revision, err
+}
+
+// WatchTopK sends the highest numbers of a leaderboard and then the new entries every time
+// they change until ctx is done, changes to the rank index are pushed by badger's Subscribe
+// - ctx: the context that ends the watch
+// - prefix: the leaderboard, empty or ending in a slash
+// - k: the maximum number of entries to send
+// - fn: called with the entries and the revision they were read at, changes arriving while it blocks are folded together
+// Returns the error from fn, or nil once ctx is done
+func (r *badgerNumberRepository) WatchTopK(ctx context.Context, prefix string, k int, fn func(entries []interfaces.RankEntry, revision uint64) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sentinel := fmt.Sprintf("%s%016x", watchSentinelPrefix, rand.Uint64())
+	live := make(chan struct{})
+	var liveOnce sync.Once
+	changed := make(chan struct{}, 1)
+
+	subscribed := make(chan error, 1)
+	go func() {
+		matches := []pb.Match{{Prefix: rankBoardPrefix(prefix)}, {Prefix: []byte(sentinel)}}
+		subscribed <- r.db.Subscribe(ctx, func(kvs *pb.KVList) error {
+			for _, kv := range kvs.GetKv() {
+				if string(kv.GetKey()) == sentinel {
+					liveOnce.Do(func() { close(live) })
+					continue
+				}
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			}
+			return nil
+		}, matches)
+	}()
+
+	if err := r.awaitSubscription(ctx, sentinel, live, subscribed); err != nil {
+		return err
+	}
+	var sent []interfaces.RankEntry
+	for ctx.Err() == nil {
+		entries, revision, err := r.TopK(prefix, k)
+		if err != nil {
+			return err
+		}
+		// writes that leave the top entries as they are do not produce an update
+		if sent == nil || !slices.Equal(entries, sent) {
+			if err := fn(entries, revision); err != nil {
+				return err
+			}
+			sent = entries
+		}
+		select {
+		case <-ctx.Done():
+		case err := <-subscribed:
+			if ctx.Err() == nil {
+				return err
+			}
+		case <-changed:
+		}
+	}
+	return nil
+}
+
+// rankBoardPrefix builds the prefix shared by the index entries of a leaderboard, the
+// leaderboard is terminated by a zero byte so one leaderboard can not match another
+func rankBoardPrefix(prefix string) []byte {
+	return []byte(rankKeyPrefix + prefix + "\x00")
+}
+
+// rankKey builds the key of a rank index entry, the value is stored inverted in big endian
+// so higher values sort first
+func rankKey(prefix string, value uint64, id string) []byte {
+	key := rankBoardPrefix(prefix)
+	key = binary.BigEndian.AppendUint64(key, ^value)
+	return append(key, id...)
+}
+
+// parseRankKey reads the value and ID of a rank index entry
+// - key: the index key
+// - offset: the length of the leaderboard prefix of the key
+// Returns interfaces.RankEntry the entry without its rank
+func parseRankKey(key []byte, offset int) interfaces.RankEntry {
+	return interfaces.RankEntry{
+		ID:    string(key[offset+8:]),
+		Value: ^binary.BigEndian.Uint64(key[offset : offset+8]),
+	}
+}
@@ -0,0 +1,259 @@
+package number
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderboards(t *testing.T) {
+	assert.Equal(t, []string{""}, leaderboards("score"))
+	assert.Equal(t, []string{"", "game/", "game/eu/"}, leaderboards("game/eu/alice"))
+}
+
+func TestBadgerNumberRepository_TopK(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBadgerNumberRepository(db)
+	top := func(prefix string, k int) []interfaces.RankEntry {
+		entries, revision, err := repo.TopK(prefix, k)
+		require.NoError(t, err)
+		assert.NotZero(t, revision)
+		return entries
+	}
+
+	require.NoError(t, repo.Save(interfaces.Number{ID: "game/eu/alice", Number: 30}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "game/eu/bob", Number: 50}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "game/us/carol", Number: 30}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "game/us/dave", Number: 10}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "other", Number: 40}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "ratio", Type: interfaces.NumberTypeFloat64, Number: 1 << 62}))
+
+	assert.Equal(t, []interfaces.RankEntry{
+		{ID: "game/eu/bob", Value: 50, Rank: 1},
+		{ID: "game/eu/alice", Value: 30, Rank: 2},
+		{ID: "game/us/carol", Value: 30, Rank: 2},
+		{ID: "game/us/dave", Value: 10, Rank: 4},
+	}, top("game/", 10))
+	assert.Equal(t, []interfaces.RankEntry{
+		{ID: "game/us/carol", Value: 30, Rank: 1},
+		{ID: "game/us/dave", Value: 10, Rank: 2},
+	}, top("game/us/", 10))
+	assert.Equal(t, []interfaces.RankEntry{
+		{ID: "game/eu/bob", Value: 50, Rank: 1},
+		{ID: "other", Value: 40, Rank: 2},
+	}, top("", 2), "numbers of other types are not ranked")
+	assert.Empty(t, top("missing/", 10))
+
+	t.Run("value changes move the entries", func(t *testing.T) {
+		_, err := repo.Update("game/us/dave", func(number *interfaces.Number) error {
+			number.Number = 60
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []interfaces.RankEntry{
+			{ID: "game/us/dave", Value: 60, Rank: 1},
+			{ID: "game/us/carol", Value: 30, Rank: 2},
+		}, top("game/us/", 10))
+		assert.Len(t, top("game/", 10), 4)
+	})
+
+	t.Run("deletes remove the entries", func(t *testing.T) {
+		require.NoError(t, repo.DeleteByID("game/eu/bob", interfaces.ChangeSource{}))
+		_, err := repo.DeleteIf("game/us/dave", func(number *interfaces.Number) error { return nil })
+		require.NoError(t, err)
+
+		assert.Equal(t, []interfaces.RankEntry{
+			{ID: "game/eu/alice", Value: 30, Rank: 1},
+			{ID: "game/us/carol", Value: 30, Rank: 1},
+		}, top("game/", 10))
+	})
+
+	t.Run("changing the type removes the entries", func(t *testing.T) {
+		_, err := repo.Update("other", func(number *interfaces.Number) error {
+			number.Type = interfaces.NumberTypeInt64
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.NotContains(t, top("", 10), interfaces.RankEntry{ID: "other", Value: 40, Rank: 1})
+		assert.Len(t, top("", 10), 2)
+	})
+
+	t.Run("entries expire with the number", func(t *testing.T) {
+		require.NoError(t, repo.Save(interfaces.Number{ID: "game/eu/eve", Number: 70, Expiry: &interfaces.Expiry{TTL: time.Second}}))
+		assert.Equal(t, "game/eu/eve", top("game/eu/", 1)[0].ID)
+
+		require.Eventually(t, func() bool {
+			return top("game/eu/", 1)[0].ID == "game/eu/alice"
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
+
+func TestBadgerNumberRepository_Rank(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBadgerNumberRepository(db)
+	require.NoError(t, repo.Save(interfaces.Number{ID: "game/alice", Number: 30}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "game/bob", Number: 50}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "game/carol", Number: 30}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "score", Number: 40}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "ratio", Type: interfaces.NumberTypeFloat64}))
+
+	for _, tt := range []struct {
+		id     string
+		prefix string
+		rank   uint64
+	}{
+		{id: "game/bob", prefix: "game/", rank: 1},
+		{id: "game/alice", prefix: "game/", rank: 2},
+		{id: "game/carol", prefix: "game/", rank: 2},
+		{id: "game/carol", prefix: "", rank: 3},
+		{id: "score", prefix: "", rank: 2},
+	} {
+		entry, revision, err := repo.Rank(tt.id, tt.prefix)
+		require.NoError(t, err)
+		assert.NotZero(t, revision)
+		assert.Equal(t, tt.id, entry.ID)
+		assert.Equal(t, tt.rank, entry.Rank, "%s on %q", tt.id, tt.prefix)
+	}
+
+	t.Run("counts at most maxRankScan higher numbers", func(t *testing.T) {
+		higher := make(map[string]uint64, maxRankScan)
+		for i := 0; i < maxRankScan; i++ {
+			higher[fmt.Sprintf("crowd/%05d", i)] = 100
+		}
+		saveLegacy(t, db, higher)
+		require.NoError(t, repo.Migrate())
+		require.NoError(t, repo.Save(interfaces.Number{ID: "crowd/last", Number: 1}))
+		require.NoError(t, repo.Save(interfaces.Number{ID: "crowd/tied", Number: 100}))
+
+		entry, _, err := repo.Rank("crowd/tied", "crowd/")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), entry.Rank)
+		_, _, err = repo.Rank("crowd/last", "crowd/")
+		assert.ErrorIs(t, err, interfaces.ErrOutOfRange)
+	})
+
+	_, _, err = repo.Rank("missing", "")
+	assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	_, _, err = repo.Rank("ratio", "")
+	assert.ErrorIs(t, err, interfaces.ErrTypeMismatch)
+}
+
+// saveLegacy writes numbers in the shape the first releases saved them, bypassing the indexes
+func saveLegacy(t *testing.T, db *badger.DB, numbers map[string]uint64) {
+	t.Helper()
+	batch := db.NewWriteBatch()
+	defer batch.Cancel()
+	for id, value := range numbers {
+		require.NoError(t, batch.Set([]byte(id), []byte(fmt.Sprintf(`{"ID":%q,"Number":%d}`, id, value))))
+	}
+	require.NoError(t, batch.Flush())
+}
+
+func TestBadgerNumberRepository_Migrate(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBadgerNumberRepository(db)
+	require.NoError(t, repo.Save(interfaces.Number{ID: "a", Number: 1}))
+	legacy := map[string]uint64{"d": 500, "game/e": 7}
+	// enough numbers to span several batches
+	for i := 0; i < rankMigrationBatch+10; i++ {
+		legacy[fmt.Sprintf("bulk/%04d", i)] = 0
+	}
+	saveLegacy(t, db, legacy)
+
+	entries, _, err := repo.TopK("", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "a", entries[0].ID, "numbers saved before the index existed are not ranked yet")
+
+	require.NoError(t, repo.Migrate())
+
+	entries, _, err = repo.TopK("", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []interfaces.RankEntry{{ID: "d", Value: 500, Rank: 1}, {ID: "game/e", Value: 7, Rank: 2}}, entries)
+	entries, _, err = repo.TopK("bulk/", maxRankScan)
+	require.NoError(t, err)
+	assert.Len(t, entries, rankMigrationBatch+10)
+	entry, _, err := repo.Rank("d", "")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), entry.Rank)
+
+	t.Run("later calls do nothing", func(t *testing.T) {
+		require.NoError(t, repo.Migrate())
+		require.NoError(t, db.View(func(txn *badger.Txn) error {
+			_, err := txn.Get([]byte(rankMigrationKey))
+			return err
+		}))
+	})
+}
+
+// boardRecorder collects the leaderboards sent by a watch
+type boardRecorder struct {
+	mu     sync.Mutex
+	boards [][]interfaces.RankEntry
+}
+
+func (r *boardRecorder) record(entries []interfaces.RankEntry, revision uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.boards = append(r.boards, entries)
+	return nil
+}
+
+// waitFor waits until the recorder holds at least n leaderboards
+func (r *boardRecorder) waitFor(t *testing.T, n int) [][]interfaces.RankEntry {
+	require.Eventually(t, func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return len(r.boards) >= n
+	}, 5*time.Second, 10*time.Millisecond)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]interfaces.RankEntry{}, r.boards...)
+}
+
+func TestBadgerNumberRepository_WatchTopK(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBadgerNumberRepository(db)
+	require.NoError(t, repo.Save(interfaces.Number{ID: "game/alice", Number: 30}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "game/bob", Number: 20}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	recorder := &boardRecorder{}
+	done := make(chan error, 1)
+	go func() { done <- repo.WatchTopK(ctx, "game/", 1, recorder.record) }()
+
+	boards := recorder.waitFor(t, 1)
+	assert.Equal(t, []interfaces.RankEntry{{ID: "game/alice", Value: 30, Rank: 1}}, boards[0])
+
+	// changes below the top entries and outside the leaderboard send nothing
+	require.NoError(t, repo.Save(interfaces.Number{ID: "game/bob", Number: 25}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "other", Number: 90}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "game/bob", Number: 40}))
+
+	boards = recorder.waitFor(t, 2)
+	assert.Len(t, boards, 2)
+	assert.Equal(t, []interfaces.RankEntry{{ID: "game/bob", Value: 40, Rank: 1}}, boards[1])
+
+	cancel()
+	assert.NoError(t, <-done)
+}
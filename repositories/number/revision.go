@@ -0,0 +1,159 @@
+package number
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+)
+
+// FindByIDAt finds a number as it was at a revision
+// - id: the ID of the number to find
+// - revision: the commit revision to read at, as reported by watches and listings
+// Returns the number, ErrNotFound if it did not exist at the revision, or ErrOutOfRange
+// if the revision is ahead of the store or the versions it needs were discarded
+func (r *badgerNumberRepository) FindByIDAt(id string, revision uint64) (*interfaces.Number, error) {
+	var number *interfaces.Number
+	err := r.db.View(func(txn *badger.Txn) error {
+		if err := checkRevision(txn, revision); err != nil {
+			return err
+		}
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(id)
+		opts.AllVersions = true
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		it.Seek([]byte(id))
+		if !it.Valid() || !bytes.Equal(it.Item().Key(), []byte(id)) {
+			return interfaces.ErrNotFound
+		}
+		var err error
+		number, err = numberAt(it, revision)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+// ListAt returns numbers in ID order as they were at a revision
+// - prefix: only numbers whose ID starts with prefix are returned
+// - after: only numbers whose ID sorts after this one are returned, empty starts at the beginning
+// - limit: the maximum number of numbers to return
+// - revision: the commit revision to read at, 0 reads the latest state
+// Returns the matching numbers and the revision they were read at, or ErrOutOfRange if the
+// revision is ahead of the store or the versions it needs were discarded
+func (r *badgerNumberRepository) ListAt(prefix string, after string, limit int, revision uint64) ([]interfaces.Number, uint64, error) {
+	numbers := []interfaces.Number{}
+	err := r.db.View(func(txn *badger.Txn) error {
+		if revision == 0 {
+			revision = txn.ReadTs()
+		}
+		if err := checkRevision(txn, revision); err != nil {
+			return err
+		}
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(prefix)
+		opts.AllVersions = true
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		start := prefix
+		if prefix == "" {
+			// reserved keys sort before every valid name, skip them in one seek
+			start = reservedKeysEnd
+		}
+		if after != "" {
+			start = max(start, after+"\x00")
+		}
+		// numberAt moves the iterator past every version of the key it reads
+		for it.Seek([]byte(start)); it.Valid() && len(numbers) < limit; {
+			number, err := numberAt(it, revision)
+			if errors.Is(err, interfaces.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			numbers = append(numbers, *number)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return numbers, revision, nil
+}
+
+// checkRevision rejects revisions the store has not reached yet
+// - txn: the reading transaction
+// - revision: the requested revision
+// Returns ErrOutOfRange if the revision is ahead of the transaction
+func checkRevision(txn *badger.Txn, revision uint64) error {
+	if revision > txn.ReadTs() {
+		return fmt.Errorf("%w: revision %d is ahead of the store at %d", interfaces.ErrOutOfRange, revision, txn.ReadTs())
+	}
+	return nil
+}
+
+// numberAt resolves the number stored under the key the iterator is on as it was at a
+// revision, versions are visited newest first and the iterator is left on the next key
+// - it: an all versions iterator positioned on the newest version of a key
+// - revision: the commit revision to read at
+// Returns the number, ErrNotFound if it did not exist at the revision, or ErrOutOfRange if
+// every retained version is newer and older ones were discarded, numbers that have
+// since expired read as absent
+func numberAt(it *badger.Iterator, revision uint64) (*interfaces.Number, error) {
+	key := it.Item().KeyCopy(nil)
+	var number *interfaces.Number
+	resolved := false
+	// created is true when the oldest retained write newer than the revision created the
+	// number for the first time, a number recreated after a delete or an expiry may have existed
+	// at the revision, an imported number may start past version 1 and numbers saved before
+	// versions were tracked can not tell, all of them count as not created
+	created := false
+	for ; it.Valid() && bytes.Equal(it.Item().Key(), key); it.Next() {
+		item := it.Item()
+		if resolved {
+			continue
+		}
+		if item.Version() > revision {
+			created = false
+			if item.ValueSize() > 0 {
+				var stored struct {
+					Version   uint64
+					Recreated bool
+				}
+				err := item.Value(func(val []byte) error {
+					return json.Unmarshal(val, &stored)
+				})
+				if err != nil {
+					return nil, err
+				}
+				created = stored.Version == 1 && !stored.Recreated
+			}
+			continue
+		}
+		resolved = true
+		if item.IsDeletedOrExpired() {
+			continue
+		}
+		number = &interfaces.Number{}
+		if err := decodeNumber(item, number); err != nil {
+			return nil, err
+		}
+	}
+	if number != nil {
+		return number, nil
+	}
+	// the number was deleted at the revision, or the first retained write created it later
+	if resolved || created {
+		return nil, fmt.Errorf("%w: %s at revision %d", interfaces.ErrNotFound, key, revision)
+	}
+	return nil, fmt.Errorf("%w: versions of %s at revision %d were discarded", interfaces.ErrOutOfRange, key, revision)
+}
@@ -0,0 +1,197 @@
+package number
+
+import (
+	"testing"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// currentRevision returns the revision reads see now
+func currentRevision(t *testing.T, db *badger.DB) uint64 {
+	var revision uint64
+	require.NoError(t, db.View(func(txn *badger.Txn) error {
+		revision = txn.ReadTs()
+		return nil
+	}))
+	return revision
+}
+
+func TestBadgerNumberRepository_FindByIDAt(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database that keeps older versions
+	opts := badger.DefaultOptions(tempDir).WithNumVersionsToKeep(10)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	set := func(id string, value uint64) uint64 {
+		require.NoError(t, repo.Save(interfaces.Number{ID: id, Number: value}))
+		return currentRevision(t, db)
+	}
+
+	beforeCreate := currentRevision(t, db)
+	first := set("report", 1)
+	second := set("report", 2)
+	set("report-other", 9)
+	require.NoError(t, repo.DeleteByID("report", interfaces.ChangeSource{}))
+	deleted := currentRevision(t, db)
+
+	t.Run("reads each revision", func(t *testing.T) {
+		number, err := repo.FindByIDAt("report", first)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), number.Number)
+
+		number, err = repo.FindByIDAt("report", second)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), number.Number)
+		assert.Equal(t, uint64(2), number.Version)
+	})
+
+	t.Run("deleted number is not found", func(t *testing.T) {
+		_, err := repo.FindByIDAt("report", deleted)
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	})
+
+	t.Run("number created later is not found", func(t *testing.T) {
+		_, err := repo.FindByIDAt("report", beforeCreate)
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	})
+
+	t.Run("unknown number is not found", func(t *testing.T) {
+		_, err := repo.FindByIDAt("repo", second)
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	})
+
+	t.Run("future revision is out of range", func(t *testing.T) {
+		_, err := repo.FindByIDAt("report", deleted+100)
+		assert.ErrorIs(t, err, interfaces.ErrOutOfRange)
+	})
+}
+
+func TestBadgerNumberRepository_FindByIDAtDiscarded(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	require.NoError(t, repo.Save(interfaces.Number{ID: "unrelated", Number: 1}))
+	before := currentRevision(t, db)
+	// a second write with nothing older retained looks like compaction discarded the first
+	require.NoError(t, db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte("compacted"), []byte(`{"ID":"compacted","Number":2,"Version":2}`))
+	}))
+
+	_, err = repo.FindByIDAt("compacted", before)
+	assert.ErrorIs(t, err, interfaces.ErrOutOfRange)
+
+	_, _, err = repo.ListAt("", "", 10, before)
+	assert.ErrorIs(t, err, interfaces.ErrOutOfRange)
+}
+
+func TestBadgerNumberRepository_FindByIDAtRecreated(t *testing.T) {
+	// Open a Badger database
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	require.NoError(t, repo.Save(interfaces.Number{ID: "cycled", Number: 1}))
+	alive := currentRevision(t, db)
+	require.NoError(t, repo.DeleteByID("cycled", interfaces.ChangeSource{}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "cycled", Number: 7}))
+
+	recreated, err := repo.FindByID("cycled")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), recreated.Version)
+	assert.True(t, recreated.Recreated)
+	number, err := repo.FindByIDAt("cycled", alive)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), number.Number, "older versions are still retained")
+
+	t.Run("first creation is not flagged", func(t *testing.T) {
+		require.NoError(t, repo.Save(interfaces.Number{ID: "fresh", Number: 1}))
+		fresh, err := repo.FindByID("fresh")
+		require.NoError(t, err)
+		assert.False(t, fresh.Recreated)
+	})
+
+	t.Run("discarded revisions before the delete are out of range", func(t *testing.T) {
+		before := currentRevision(t, db)
+		// the recreating write with nothing older retained looks like compaction discarded the
+		// first number and its tombstone
+		require.NoError(t, db.Update(func(txn *badger.Txn) error {
+			return txn.Set([]byte("compacted"), []byte(`{"ID":"compacted","Number":2,"Version":1,"Recreated":true}`))
+		}))
+
+		_, err := repo.FindByIDAt("compacted", before)
+		assert.ErrorIs(t, err, interfaces.ErrOutOfRange)
+	})
+}
+
+func TestBadgerNumberRepository_ListAt(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database that keeps older versions
+	opts := badger.DefaultOptions(tempDir).WithNumVersionsToKeep(10)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	require.NoError(t, repo.Save(interfaces.Number{ID: "a", Number: 1}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "b", Number: 1}))
+	snapshot := currentRevision(t, db)
+	require.NoError(t, repo.Save(interfaces.Number{ID: "a", Number: 2}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "c", Number: 1}))
+	require.NoError(t, repo.DeleteByID("b", interfaces.ChangeSource{}))
+
+	t.Run("lists the snapshot", func(t *testing.T) {
+		numbers, revision, err := repo.ListAt("", "", 10, snapshot)
+		require.NoError(t, err)
+		assert.Equal(t, snapshot, revision)
+		require.Len(t, numbers, 2)
+		assert.Equal(t, "a", numbers[0].ID)
+		assert.Equal(t, uint64(1), numbers[0].Number)
+		assert.Equal(t, "b", numbers[1].ID)
+	})
+
+	t.Run("zero revision lists the latest state", func(t *testing.T) {
+		numbers, revision, err := repo.ListAt("", "", 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, currentRevision(t, db), revision)
+		require.Len(t, numbers, 2)
+		assert.Equal(t, uint64(2), numbers[0].Number)
+		assert.Equal(t, "c", numbers[1].ID)
+	})
+
+	t.Run("pages resume after a name", func(t *testing.T) {
+		numbers, _, err := repo.ListAt("", "a", 1, snapshot)
+		require.NoError(t, err)
+		require.Len(t, numbers, 1)
+		assert.Equal(t, "b", numbers[0].ID)
+	})
+
+	t.Run("reserved keys are skipped", func(t *testing.T) {
+		numbers, _, err := repo.ListAt("", "", 10, 0)
+		require.NoError(t, err)
+		for _, number := range numbers {
+			assert.NotContains(t, number.ID, interfaces.ReservedKeyPrefix)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package number
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/repositories/retry"
+	"github.com/dgraph-io/badger/v4"
+)
+
+// idempotencyKeyPrefix prefixes the idempotency records stored next to the numbers
+const idempotencyKeyPrefix = interfaces.ReservedKeyPrefix + "idempotency/"
+
+// badgerNumberTxn implements interfaces.INumberTxn on top of a badger read-write transaction
+type badgerNumberTxn struct {
+	txn *badger.Txn
+	// written holds the IDs staged so far, reported when the transaction keeps conflicting
+	written []string
+	// historyRetention is how long history entries are kept, 0 keeps them forever
+	historyRetention time.Duration
+}
+
+// Get returns a number as seen by the transaction, including its own writes
+// - id: the ID of the number to get
+// Returns the stored number, or a zero value number with the ID set when none exists yet
+func (t *badgerNumberTxn) Get(id string) (*interfaces.Number, error) {
+	number := interfaces.Number{ID: id}
+	item, err := t.txn.Get([]byte(id))
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return &number, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeNumber(item, &number); err != nil {
+		return nil, err
+	}
+	return &number, nil
+}
+
+// Put stages a number to be saved when the transaction commits, the version is
+// incremented from the one the transaction sees, the update time is set and the creation
+// time is kept from the stored number or set on the first write, numbers
+// with an expiry are stored with a badger TTL, the requested change is added
+// to the current bucket of each window of the number, a history entry is staged and
+// the label index is updated
+// - number: the number to save, its version, creation, update and expiry time are updated in
+// place and its change is cleared once staged
+// Returns an error if the write can not be staged
+func (t *badgerNumberTxn) Put(number *interfaces.Number) error {
+	stored, err := t.Get(number.ID)
+	if err != nil {
+		return err
+	}
+	// a version set past the next one is kept, an import restores the version it exported
+	number.Version = max(number.Version, stored.Version+1)
+	number.UpdatedAt = time.Now().UTC()
+	number.CreatedAt = stored.CreatedAt
+	number.Recreated = stored.Recreated
+	if stored.Version == 0 {
+		number.CreatedAt = number.UpdatedAt
+		number.Recreated = t.hasEarlierVersions(number.ID)
+	}
+	// the requested change is summed rather than the difference of the values, a bounded
+	// wrap or an absolute write would otherwise count as a change it is not
+	if number.Change != 0 {
+		if err := t.addToWindows(number, number.Change, number.UpdatedAt); err != nil {
+			return err
+		}
+		number.Change = 0
+	}
+	if err := t.addToHistory(number, stored); err != nil {
+		return err
+	}
+	data, err := json.Marshal(number)
+	if err != nil {
+		return err
+	}
+	entry := badger.NewEntry([]byte(number.ID), data)
+	if number.Expiry != nil {
+		if number.Expiry.Sliding || number.ExpiresAt.IsZero() {
+			entry = entry.WithTTL(number.Expiry.TTL)
+		} else {
+			// fixed expiry keeps the deadline started by an earlier write
+			entry.ExpiresAt = uint64(number.ExpiresAt.Unix())
+		}
+	}
+	if err := t.indexLabels(stored, number, entry.ExpiresAt); err != nil {
+		return err
+	}
+	if err := t.indexRank(stored, number, entry.ExpiresAt); err != nil {
+		return err
+	}
+	number.ExpiresAt = expiresAt(entry.ExpiresAt)
+	t.written = append(t.written, number.ID)
+	return t.txn.SetEntry(entry)
+}
+
+// hasEarlierVersions reports whether a key still holds versions of an earlier number, the
+// tombstone of a delete or the value of an expired number
+// - id: the ID of a number that does not exist
+// Returns true if any version of the key is retained
+func (t *badgerNumberTxn) hasEarlierVersions(id string) bool {
+	opts := badger.DefaultIteratorOptions
+	opts.AllVersions = true
+	opts.PrefetchValues = false
+	opts.Prefix = []byte(id)
+	it := t.txn.NewIterator(opts)
+	defer it.Close()
+	it.Seek([]byte(id))
+	return it.Valid() && string(it.Item().Key()) == id
+}
+
+// GetIdempotency returns the record stored for an idempotency key of a number
+// - id: the ID of the number the key belongs to
+// - key: the idempotency key
+// Returns the record, or nil if the key has not been used or its record expired
+func (t *badgerNumberTxn) GetIdempotency(id string, key string) (*interfaces.IdempotencyRecord, error) {
+	item, err := t.txn.Get(idempotencyKey(id, key))
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var record interfaces.IdempotencyRecord
+	err = item.Value(func(val []byte) error {
+		return json.Unmarshal(val, &record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// PutIdempotency stages the record for an idempotency key of a number, badger
+// drops it once the ttl passes
+// - id: the ID of the number the key belongs to
+// - key: the idempotency key
+// - record: the outcome to replay for later requests with the same key
+// - ttl: how long the record is kept, 0 keeps it forever
+// Returns an error if the write can not be staged
+func (t *badgerNumberTxn) PutIdempotency(id string, key string, record interfaces.IdempotencyRecord, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	entry := badger.NewEntry(idempotencyKey(id, key), data)
+	if ttl > 0 {
+		entry = entry.WithTTL(ttl)
+	}
+	return t.txn.SetEntry(entry)
+}
+
+// idempotencyKey builds the key of an idempotency record, the number ID is
+// terminated by a zero byte so IDs sharing a prefix can not collide
+func idempotencyKey(id string, key string) []byte {
+	return []byte(idempotencyKeyPrefix + id + "\x00" + key)
+}
+
+// Transact runs fn inside a single badger transaction and retries it on conflicts
+// - fn: reads and stages writes through the transaction, returning an error discards the writes
+// Returns the error from fn, or a ConflictError once retries run out
+func (r *badgerNumberRepository) Transact(fn func(txn interfaces.INumberTxn) error) error {
+	var numberTxn *badgerNumberTxn
+	err := retry.OnConflict("", func() error {
+		return r.db.Update(func(txn *badger.Txn) error {
+			numberTxn = &badgerNumberTxn{txn: txn, historyRetention: r.historyRetention}
+			return fn(numberTxn)
+		})
+	})
+	var conflict *interfaces.ConflictError
+	if errors.As(err, &conflict) {
+		conflict.ID = strings.Join(numberTxn.written, ",")
+	}
+	return err
+}
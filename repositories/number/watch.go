@@ -0,0 +1,227 @@
+package number
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/dgraph-io/badger/v4/pb"
+)
+
+const (
+	// watchSentinelPrefix prefixes the short lived keys used to detect when a subscription is live
+	watchSentinelPrefix = interfaces.ReservedKeyPrefix + "watch/"
+	// watchSentinelDelay is how long the first sentinel write is given to come through, the
+	// delay doubles with every rewrite
+	watchSentinelDelay = 10 * time.Millisecond
+	// maxWatchSentinelDelay caps the delay between two sentinel writes
+	maxWatchSentinelDelay = time.Second
+)
+
+// Watch sends the current state of numbers and then every change to them until ctx is done,
+// changes are pushed by badger's Subscribe rather than polled
+// - ctx: the context that ends the watch
+// - prefix: only numbers whose ID starts with prefix are watched
+// - since: only numbers changed after this revision are part of the initial state, 0 sends all
+// - fn: called for every event in revision order, it must not block
+// Returns the error from fn, or nil once ctx is done
+func (r *badgerNumberRepository) Watch(ctx context.Context, prefix string, since uint64, fn func(event interfaces.NumberEvent) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sentinel := fmt.Sprintf("%s%016x", watchSentinelPrefix, rand.Uint64())
+	live := make(chan struct{})
+	var liveOnce sync.Once
+
+	// live changes are held back until the initial state is sent, changes
+	// already covered by the snapshot are then dropped by revision
+	var mu sync.Mutex
+	var pending []interfaces.NumberEvent
+	synced := false
+	var snapshotRevision uint64
+
+	subscribed := make(chan error, 1)
+	go func() {
+		matches := []pb.Match{{Prefix: []byte(prefix)}, {Prefix: []byte(sentinel)}}
+		subscribed <- r.db.Subscribe(ctx, func(kvs *pb.KVList) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, kv := range kvs.GetKv() {
+				key := string(kv.GetKey())
+				if key == sentinel {
+					liveOnce.Do(func() { close(live) })
+					continue
+				}
+				if strings.HasPrefix(key, interfaces.ReservedKeyPrefix) {
+					continue
+				}
+				event, err := decodeEvent(key, kv.GetValue(), kv.GetVersion(), kv.GetExpiresAt())
+				if err != nil {
+					return err
+				}
+				if !synced {
+					pending = append(pending, event)
+					continue
+				}
+				if event.Revision <= snapshotRevision {
+					continue
+				}
+				if err := fn(event); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, matches)
+	}()
+
+	// the subscription registers asynchronously, keep touching the sentinel
+	// until it comes through so no change can slip in before the snapshot
+	if err := r.awaitSubscription(ctx, sentinel, live, subscribed); err != nil {
+		return err
+	}
+
+	revision, err := r.snapshot(prefix, since, fn)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	err = fn(interfaces.NumberEvent{Synced: true, Revision: revision})
+	for _, event := range pending {
+		if err != nil {
+			break
+		}
+		if event.Revision > revision {
+			err = fn(event)
+		}
+	}
+	pending = nil
+	synced = true
+	snapshotRevision = revision
+	mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = <-subscribed
+	if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// awaitSubscription writes the sentinel key until the subscription reports it, the subscription
+// usually registers before the first write lands so rewrites back off instead of churning
+// - ctx: the watch context
+// - sentinel: the sentinel key matched by the subscription
+// - live: closed by the subscription once it sees the sentinel
+// - subscribed: receives the result of Subscribe if it ends early
+// Returns nil once the subscription is live, otherwise the reason it is not
+func (r *badgerNumberRepository) awaitSubscription(ctx context.Context, sentinel string, live <-chan struct{}, subscribed <-chan error) error {
+	defer func() {
+		_ = r.db.Update(func(txn *badger.Txn) error {
+			return txn.Delete([]byte(sentinel))
+		})
+	}()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	delay := watchSentinelDelay
+	for {
+		select {
+		case <-live:
+			return nil
+		case err := <-subscribed:
+			return err
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+		err := r.db.Update(func(txn *badger.Txn) error {
+			return txn.SetEntry(badger.NewEntry([]byte(sentinel), []byte{1}).WithTTL(time.Minute))
+		})
+		if err != nil {
+			return err
+		}
+		timer.Reset(delay)
+		delay = min(2*delay, maxWatchSentinelDelay)
+	}
+}
+
+// snapshot sends the current state of the watched numbers
+// - prefix: only numbers whose ID starts with prefix are sent
+// - since: only numbers changed after this revision are sent, deletions are included when it is set
+// - fn: called for every number
+// Returns the revision the snapshot was read at
+func (r *badgerNumberRepository) snapshot(prefix string, since uint64, fn func(event interfaces.NumberEvent) error) (uint64, error) {
+	var revision uint64
+	err := r.db.View(func(txn *badger.Txn) error {
+		revision = txn.ReadTs()
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(prefix)
+		// deletions are only visible as tombstones when iterating every version
+		opts.AllVersions = since > 0
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		start := prefix
+		if prefix == "" {
+			// reserved keys sort before every valid name, skip them in one seek
+			start = reservedKeysEnd
+		}
+		previous := ""
+		for it.Seek([]byte(start)); it.Valid(); it.Next() {
+			item := it.Item()
+			key := string(item.Key())
+			// versions of a key are visited newest first, only the newest matters
+			if key == previous || strings.HasPrefix(key, interfaces.ReservedKeyPrefix) {
+				continue
+			}
+			previous = key
+			if item.Version() <= since {
+				continue
+			}
+			if item.IsDeletedOrExpired() {
+				if err := fn(interfaces.NumberEvent{Number: interfaces.Number{ID: key}, Deleted: true, Revision: item.Version()}); err != nil {
+					return err
+				}
+				continue
+			}
+			var value []byte
+			value, err := item.ValueCopy(value)
+			if err != nil {
+				return err
+			}
+			event, err := decodeEvent(key, value, item.Version(), item.ExpiresAt())
+			if err != nil {
+				return err
+			}
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return revision, err
+}
+
+// decodeEvent turns a stored key value pair into a number event
+// - key: the number ID
+// - value: the stored value, empty for deletions
+// - revision: the commit revision of the change
+// - expiry: unix seconds when the entry expires, 0 if it never does
+// Returns the event, or an error if the value can not be decoded
+func decodeEvent(key string, value []byte, revision uint64, expiry uint64) (interfaces.NumberEvent, error) {
+	event := interfaces.NumberEvent{Number: interfaces.Number{ID: key}, Revision: revision}
+	if len(value) == 0 {
+		event.Deleted = true
+		return event, nil
+	}
+	err := unmarshalNumber(value, &event.Number)
+	event.Number.ExpiresAt = expiresAt(expiry)
+	return event, err
+}
@@ -0,0 +1,117 @@
+package number
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventRecorder collects watch events for assertions
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []interfaces.NumberEvent
+}
+
+func (r *eventRecorder) record(event interfaces.NumberEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *eventRecorder) snapshot() []interfaces.NumberEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]interfaces.NumberEvent{}, r.events...)
+}
+
+// waitFor waits until the recorder holds at least n events
+func (r *eventRecorder) waitFor(t *testing.T, n int) []interfaces.NumberEvent {
+	require.Eventually(t, func() bool {
+		return len(r.snapshot()) >= n
+	}, 5*time.Second, 10*time.Millisecond)
+	return r.snapshot()
+}
+
+func TestBadgerNumberRepository_Watch(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	require.NoError(t, repo.Save(interfaces.Number{ID: "jobs/a", Number: 1}))
+	require.NoError(t, repo.Save(interfaces.Number{ID: "other", Number: 1}))
+
+	t.Run("sends current state then changes", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		recorder := &eventRecorder{}
+		done := make(chan error, 1)
+		go func() { done <- repo.Watch(ctx, "jobs/", 0, recorder.record) }()
+
+		events := recorder.waitFor(t, 2)
+		assert.Equal(t, "jobs/a", events[0].Number.ID)
+		assert.True(t, events[1].Synced)
+
+		require.NoError(t, repo.Save(interfaces.Number{ID: "other", Number: 2}))
+		require.NoError(t, repo.Save(interfaces.Number{ID: "jobs/b", Number: 5}))
+		require.NoError(t, repo.DeleteByID("jobs/a", interfaces.ChangeSource{}))
+
+		events = recorder.waitFor(t, 4)
+		assert.Equal(t, "jobs/b", events[2].Number.ID)
+		assert.Equal(t, uint64(5), events[2].Number.Number)
+		assert.Greater(t, events[2].Revision, events[1].Revision)
+		assert.Equal(t, "jobs/a", events[3].Number.ID)
+		assert.True(t, events[3].Deleted)
+
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("resumes after a revision", func(t *testing.T) {
+		// find the current revision with a watch that only reads the initial state
+		ctx, cancel := context.WithCancel(context.Background())
+		recorder := &eventRecorder{}
+		go func() { _ = repo.Watch(ctx, "jobs/", 0, recorder.record) }()
+		events := recorder.waitFor(t, 2)
+		cancel()
+		revision := events[len(events)-1].Revision
+
+		require.NoError(t, repo.Save(interfaces.Number{ID: "jobs/c", Number: 9}))
+		require.NoError(t, repo.DeleteByID("jobs/b", interfaces.ChangeSource{}))
+
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+		recorder = &eventRecorder{}
+		go func() { _ = repo.Watch(ctx, "jobs/", revision, recorder.record) }()
+		events = recorder.waitFor(t, 3)
+		assert.Equal(t, "jobs/b", events[0].Number.ID)
+		assert.True(t, events[0].Deleted)
+		assert.Equal(t, "jobs/c", events[1].Number.ID)
+		assert.Equal(t, uint64(9), events[1].Number.Number)
+		assert.True(t, events[2].Synced)
+	})
+
+	t.Run("watching every number skips reserved keys", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		recorder := &eventRecorder{}
+		go func() { _ = repo.Watch(ctx, "", 0, recorder.record) }()
+
+		// history, rank and window keys written above sort before every number
+		events := recorder.waitFor(t, 3)
+		assert.Equal(t, []string{"jobs/c", "other"}, []string{events[0].Number.ID, events[1].Number.ID})
+		assert.True(t, events[2].Synced)
+	})
+}
@@ -0,0 +1,120 @@
+package number
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+)
+
+// windowKeyPrefix prefixes the window buckets stored next to the numbers
+const windowKeyPrefix = interfaces.ReservedKeyPrefix + "window/"
+
+// addToWindows adds a change of a number to the current bucket of each of its windows,
+// buckets expire once their retention has passed
+// - number: the number that changed
+// - delta: the change of its value
+// - at: when the change happened
+// Returns an error if a bucket can not be read or staged
+func (t *badgerNumberTxn) addToWindows(number *interfaces.Number, delta int64, at time.Time) error {
+	for _, window := range number.Windows {
+		start := at.Truncate(window.Resolution)
+		key := windowKey(number.ID, window.Resolution, start)
+		var sum int64
+		item, err := t.txn.Get(key)
+		if err != nil && !errors.Is(err, badger.ErrKeyNotFound) {
+			return err
+		}
+		if err == nil {
+			err = item.Value(func(val []byte) error {
+				return json.Unmarshal(val, &sum)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		data, err := json.Marshal(sum + delta)
+		if err != nil {
+			return err
+		}
+		entry := badger.NewEntry(key, data)
+		entry.ExpiresAt = uint64(start.Add(window.Resolution + window.Retention).Unix())
+		if err := t.txn.SetEntry(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Buckets returns the non empty buckets of a window of a number in time order
+// - id: the ID of the number
+// - resolution: the resolution of the window
+// - from: only buckets starting at or after this time are returned
+// - to: only buckets starting before this time are returned
+// Returns the buckets, or an error if the scan fails
+func (r *badgerNumberRepository) Buckets(id string, resolution time.Duration, from time.Time, to time.Time) ([]interfaces.WindowBucket, error) {
+	buckets := []interfaces.WindowBucket{}
+	err := r.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = windowPrefix(id, resolution)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		end := string(windowKey(id, resolution, to))
+		for it.Seek(windowKey(id, resolution, from)); it.Valid() && string(it.Item().Key()) < end; it.Next() {
+			item := it.Item()
+			key := item.Key()
+			bucket := interfaces.WindowBucket{Start: time.Unix(int64(binary.BigEndian.Uint64(key[len(key)-8:])), 0).UTC()}
+			err := item.Value(func(val []byte) error {
+				return json.Unmarshal(val, &bucket.Sum)
+			})
+			if err != nil {
+				return err
+			}
+			buckets = append(buckets, bucket)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// deleteWindows stages the deletion of every window bucket of a number
+// - txn: the transaction deleting the number
+// - id: the ID of the number
+// Returns an error if a deletion can not be staged
+func deleteWindows(txn *badger.Txn, id string) error {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = []byte(windowKeyPrefix + id + "\x00")
+	opts.PrefetchValues = false
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	keys := [][]byte{}
+	for it.Rewind(); it.Valid(); it.Next() {
+		keys = append(keys, it.Item().KeyCopy(nil))
+	}
+	for _, key := range keys {
+		if err := txn.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// windowPrefix builds the key prefix shared by the buckets of a window, the number ID
+// is terminated by a zero byte so IDs sharing a prefix can not collide
+func windowPrefix(id string, resolution time.Duration) []byte {
+	return binary.BigEndian.AppendUint64([]byte(windowKeyPrefix+id+"\x00"), uint64(resolution/time.Second))
+}
+
+// windowKey builds the key of a window bucket, the start is big endian so the
+// buckets of a window sort by time
+func windowKey(id string, resolution time.Duration, start time.Time) []byte {
+	return binary.BigEndian.AppendUint64(windowPrefix(id, resolution), uint64(max(start.Unix(), 0)))
+}
@@ -0,0 +1,116 @@
+package number
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sumBuckets(buckets []interfaces.WindowBucket) int64 {
+	var sum int64
+	for _, bucket := range buckets {
+		sum += bucket.Sum
+	}
+	return sum
+}
+
+func TestBadgerNumberRepository_Buckets(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerNumberRepository(db)
+	windows := []interfaces.Window{
+		{Resolution: time.Minute, Retention: time.Hour},
+		{Resolution: time.Hour, Retention: 24 * time.Hour},
+	}
+	add := func(id string, delta int64) {
+		_, err := repo.Update(id, func(number *interfaces.Number) error {
+			if number.Version == 0 {
+				number.Windows = windows
+			}
+			number.Number = uint64(int64(number.Number) + delta)
+			number.Change = delta
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	from := time.Now().Add(-2 * time.Hour)
+	to := time.Now().Add(2 * time.Hour)
+
+	add("hits", 5)
+	add("hits", 3)
+	add("hits", -2)
+	add("hitsx", 100)
+
+	t.Run("sums changes per bucket", func(t *testing.T) {
+		for _, window := range windows {
+			buckets, err := repo.Buckets("hits", window.Resolution, from, to)
+			require.NoError(t, err)
+			assert.NotEmpty(t, buckets)
+			assert.Equal(t, int64(6), sumBuckets(buckets))
+			for _, bucket := range buckets {
+				assert.Equal(t, bucket.Start, bucket.Start.Truncate(window.Resolution))
+			}
+		}
+	})
+
+	t.Run("writes without a change are not counted", func(t *testing.T) {
+		_, err := repo.Update("hits", func(number *interfaces.Number) error {
+			number.Number = 1000
+			return nil
+		})
+		require.NoError(t, err)
+
+		buckets, err := repo.Buckets("hits", time.Minute, from, to)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), sumBuckets(buckets))
+	})
+
+	t.Run("range excludes other buckets", func(t *testing.T) {
+		buckets, err := repo.Buckets("hits", time.Minute, from, from.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, buckets)
+	})
+
+	t.Run("unknown resolution is empty", func(t *testing.T) {
+		buckets, err := repo.Buckets("hits", time.Second, from, to)
+		require.NoError(t, err)
+		assert.Empty(t, buckets)
+	})
+
+	t.Run("numbers without windows have no buckets", func(t *testing.T) {
+		require.NoError(t, repo.Save(interfaces.Number{ID: "plain", Number: 4}))
+		buckets, err := repo.Buckets("plain", time.Minute, from, to)
+		require.NoError(t, err)
+		assert.Empty(t, buckets)
+	})
+
+	t.Run("buckets are not numbers", func(t *testing.T) {
+		ids, err := repo.ListIDs("", "", 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"hits", "hitsx", "plain"}, ids)
+	})
+
+	t.Run("delete drops buckets", func(t *testing.T) {
+		_, err := repo.DeleteIf("hits", func(number *interfaces.Number) error { return nil })
+		require.NoError(t, err)
+		buckets, err := repo.Buckets("hits", time.Minute, from, to)
+		require.NoError(t, err)
+		assert.Empty(t, buckets)
+
+		buckets, err = repo.Buckets("hitsx", time.Minute, from, to)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), sumBuckets(buckets))
+	})
+}
@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/repositories/retry"
+	"github.com/dgraph-io/badger/v4"
+)
+
+// keyPrefix prefixes the rate limit states, it is reserved so they never show up as numbers
+const keyPrefix = interfaces.ReservedKeyPrefix + "ratelimit/"
+
+type badgerRateLimitRepository struct {
+	db *badger.DB
+}
+
+// NewBadgerRateLimitRepository creates a new badgerRateLimitRepository instance
+func NewBadgerRateLimitRepository(db *badger.DB) interfaces.IRateLimitRepository {
+	return &badgerRateLimitRepository{db: db}
+}
+
+// Update atomically reads, modifies and saves the state of a key inside a single
+// transaction, badger drops the state once the ttl passes without another write
+// - key: the rate limited key
+// - ttl: how long the state is kept after this write, 0 keeps it forever
+// - fn: called with the stored state, or a zero value state when none exists yet
+// Returns the saved state, the error from fn, or a ConflictError once retries run out
+func (r *badgerRateLimitRepository) Update(key string, ttl time.Duration, fn func(state *interfaces.RateLimitState) error) (*interfaces.RateLimitState, error) {
+	var state interfaces.RateLimitState
+	err := retry.OnConflict(key, func() error {
+		return r.db.Update(func(txn *badger.Txn) error {
+			state = interfaces.RateLimitState{Key: key}
+			item, err := txn.Get([]byte(keyPrefix + key))
+			if err != nil && !errors.Is(err, badger.ErrKeyNotFound) {
+				return err
+			}
+			if err == nil {
+				err = item.Value(func(val []byte) error {
+					return json.Unmarshal(val, &state)
+				})
+				if err != nil {
+					return err
+				}
+			}
+			if err := fn(&state); err != nil {
+				return err
+			}
+			state.Key = key
+			data, err := json.Marshal(state)
+			if err != nil {
+				return err
+			}
+			entry := badger.NewEntry([]byte(keyPrefix+key), data)
+			if ttl > 0 {
+				// badger expires entries in whole seconds, the extra second keeps
+				// the state for at least ttl
+				entry = entry.WithTTL(ttl + time.Second)
+			}
+			return txn.SetEntry(entry)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
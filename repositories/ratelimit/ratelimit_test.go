@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/repositories/number"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBadgerRateLimitRepository(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerRateLimitRepository(db)
+	assert.NotNil(t, repo)
+}
+
+func TestBadgerRateLimitRepository_Update(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerRateLimitRepository(db)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("creates missing state", func(t *testing.T) {
+		state, err := repo.Update("api/a", time.Minute, func(state *interfaces.RateLimitState) error {
+			assert.Equal(t, interfaces.RateLimitState{Key: "api/a"}, *state)
+			state.Tokens = 4.5
+			state.UpdatedAt = now
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 4.5, state.Tokens)
+	})
+
+	t.Run("reads stored state", func(t *testing.T) {
+		_, err := repo.Update("api/a", time.Minute, func(state *interfaces.RateLimitState) error {
+			assert.Equal(t, 4.5, state.Tokens)
+			assert.Equal(t, now, state.UpdatedAt)
+			state.Log = []interfaces.RateLimitEntry{{At: now, Cost: 2}}
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = repo.Update("api/a", time.Minute, func(state *interfaces.RateLimitState) error {
+			assert.Equal(t, []interfaces.RateLimitEntry{{At: now, Cost: 2}}, state.Log)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("fn error keeps the state", func(t *testing.T) {
+		failure := errors.New("failure")
+		_, err := repo.Update("api/a", time.Minute, func(state *interfaces.RateLimitState) error {
+			state.Tokens = 0
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		_, err = repo.Update("api/a", time.Minute, func(state *interfaces.RateLimitState) error {
+			assert.Equal(t, 4.5, state.Tokens)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("states are not numbers", func(t *testing.T) {
+		ids, err := number.NewBadgerNumberRepository(db).ListIDs("", "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("states expire after the ttl", func(t *testing.T) {
+		_, err := repo.Update("api/short", time.Millisecond, func(state *interfaces.RateLimitState) error {
+			state.Tokens = 1
+			return nil
+		})
+		require.NoError(t, err)
+
+		// badger drops the state once its ttl passes
+		assert.Eventually(t, func() bool {
+			err := db.View(func(txn *badger.Txn) error {
+				_, err := txn.Get([]byte(keyPrefix + "api/short"))
+				return err
+			})
+			return errors.Is(err, badger.ErrKeyNotFound)
+		}, 4*time.Second, 100*time.Millisecond)
+	})
+}
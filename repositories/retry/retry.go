@@ -0,0 +1,39 @@
+package retry
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+)
+
+const (
+	// maxAttempts is how many times a conflicting transaction is tried before giving up
+	maxAttempts = 10
+	// baseDelay is the backoff before the first retry, it doubles with every attempt
+	baseDelay = 2 * time.Millisecond
+	// maxDelay caps the backoff between two attempts
+	maxDelay = 100 * time.Millisecond
+)
+
+// OnConflict runs op until it commits without a badger transaction conflict
+// - id: the ID of the record op writes, used in the returned ConflictError
+// - op: the transaction to run
+// Returns the error from op, or a ConflictError once maxAttempts is reached
+func OnConflict(id string, op func() error) error {
+	delay := baseDelay
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if !errors.Is(err, badger.ErrConflict) {
+			return err
+		}
+		if attempt == maxAttempts {
+			return &interfaces.ConflictError{ID: id, Attempts: attempt}
+		}
+		// full jitter keeps concurrent writers from retrying in lock step
+		time.Sleep(rand.N(delay) + 1)
+		delay = min(delay*2, maxDelay)
+	}
+}
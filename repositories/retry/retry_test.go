@@ -0,0 +1,57 @@
+package retry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnConflict(t *testing.T) {
+
+	t.Run("returns once op commits", func(t *testing.T) {
+		attempts := 0
+
+		err := OnConflict("busy", func() error {
+			attempts++
+			if attempts < 3 {
+				return badger.ErrConflict
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		attempts := 0
+		failure := errors.New("failure")
+
+		err := OnConflict("busy", func() error {
+			attempts++
+			return failure
+		})
+
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		attempts := 0
+
+		err := OnConflict("busy", func() error {
+			attempts++
+			return badger.ErrConflict
+		})
+
+		var conflict *interfaces.ConflictError
+		assert.ErrorAs(t, err, &conflict)
+		assert.ErrorIs(t, err, interfaces.ErrConflict)
+		assert.Equal(t, "busy", conflict.ID)
+		assert.Equal(t, maxAttempts, conflict.Attempts)
+		assert.Equal(t, maxAttempts, attempts)
+	})
+}
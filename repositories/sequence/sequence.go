@@ -0,0 +1,76 @@
+package sequence
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/dgraph-io/badger/v4"
+)
+
+// keyPrefix prefixes the sequence leases, it is reserved so they never show up as numbers
+const keyPrefix = interfaces.ReservedKeyPrefix + "sequence/"
+
+type badgerSequenceRepository struct {
+	db        *badger.DB
+	bandwidth uint64
+	mu        sync.Mutex
+	sequences map[string]*badger.Sequence
+	closed    bool
+}
+
+// NewBadgerSequenceRepository creates a new badgerSequenceRepository instance
+// - db: the database leases are stored in
+// - bandwidth: how many IDs a sequence leases at once, a crash skips the unused part of a lease
+func NewBadgerSequenceRepository(db *badger.DB, bandwidth uint64) interfaces.ISequenceRepository {
+	return &badgerSequenceRepository{db: db, bandwidth: max(bandwidth, 1), sequences: map[string]*badger.Sequence{}}
+}
+
+// Next reserves a contiguous range of IDs, only running out of a lease touches the database
+// - name: the name of the sequence
+// - count: how many IDs to reserve
+// Returns the first ID of the range, or ErrClosed once the repository is closed
+func (r *badgerSequenceRepository) Next(name string, count uint64) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return 0, interfaces.ErrClosed
+	}
+	seq, ok := r.sequences[name]
+	if !ok {
+		var err error
+		seq, err = r.db.GetSequence([]byte(keyPrefix+name), r.bandwidth)
+		if err != nil {
+			return 0, err
+		}
+		r.sequences[name] = seq
+	}
+	// holding the lock keeps the range contiguous, badger sequences start at 0
+	first, err := seq.Next()
+	if err != nil {
+		return 0, err
+	}
+	for i := uint64(1); i < count; i++ {
+		if _, err := seq.Next(); err != nil {
+			return 0, err
+		}
+	}
+	return first + 1, nil
+}
+
+// Close releases the leased but unused IDs of every sequence so a restart continues
+// right after the last ID handed out
+// Returns the errors from releasing the leases
+func (r *badgerSequenceRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	var errs []error
+	for name, seq := range r.sequences {
+		if err := seq.Release(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(r.sequences, name)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,135 @@
+package sequence
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/repositories/number"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBadgerSequenceRepository(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerSequenceRepository(db, 10)
+	assert.NotNil(t, repo)
+	assert.NoError(t, repo.Close())
+}
+
+func TestBadgerSequenceRepository_Next(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Create a new repository
+	repo := NewBadgerSequenceRepository(db, 10)
+	defer repo.Close()
+
+	t.Run("ranges are contiguous across leases", func(t *testing.T) {
+		first, err := repo.Next("orders", 1)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), first)
+
+		first, err = repo.Next("orders", 25)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), first)
+
+		first, err = repo.Next("orders", 1)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(27), first)
+	})
+
+	t.Run("sequences are independent", func(t *testing.T) {
+		first, err := repo.Next("invoices", 1)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), first)
+	})
+
+	t.Run("concurrent callers never share IDs", func(t *testing.T) {
+		var mu sync.Mutex
+		seen := map[uint64]bool{}
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					first, err := repo.Next("shared", 3)
+					assert.NoError(t, err)
+					mu.Lock()
+					for id := first; id < first+3; id++ {
+						assert.False(t, seen[id], "id %d handed out twice", id)
+						seen[id] = true
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Len(t, seen, 8*50*3)
+	})
+
+	t.Run("leases are not numbers", func(t *testing.T) {
+		ids, err := number.NewBadgerNumberRepository(db).ListIDs("", "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+}
+
+func TestBadgerSequenceRepository_Close(t *testing.T) {
+	// Create a temporary directory for the database
+	tempDir := t.TempDir()
+
+	// Open a Badger database
+	opts := badger.DefaultOptions(tempDir)
+	db, err := badger.Open(opts)
+	require.NoError(t, err)
+	defer db.Close()
+
+	t.Run("released leases continue without a gap", func(t *testing.T) {
+		repo := NewBadgerSequenceRepository(db, 100)
+		first, err := repo.Next("orders", 5)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), first)
+		require.NoError(t, repo.Close())
+
+		_, err = repo.Next("orders", 1)
+		assert.ErrorIs(t, err, interfaces.ErrClosed)
+
+		repo = NewBadgerSequenceRepository(db, 100)
+		defer repo.Close()
+		first, err = repo.Next("orders", 1)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(6), first)
+	})
+
+	t.Run("unreleased leases leave a gap", func(t *testing.T) {
+		crashed := NewBadgerSequenceRepository(db, 100)
+		first, err := crashed.Next("events", 5)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), first)
+
+		// a new repository without closing the first one acts like a restart after a crash
+		repo := NewBadgerSequenceRepository(db, 100)
+		defer repo.Close()
+		first, err = repo.Next("events", 1)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(101), first)
+	})
+}
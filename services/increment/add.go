@@ -0,0 +1,91 @@
+package increment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Add applies a signed delta to the named counter in a single atomic update,
+// requests with an idempotency key that was already used replay the first response
+// - ctx: context.Context context
+// - req: *api_v1.AddRequest request
+// Returns *api_v1.AddResponse response, OutOfRange if the result does not fit the counter,
+// or FailedPrecondition if the delta does not fit the type of the counter
+func (s *ServiceImpl) Add(ctx context.Context, req *api_v1.AddRequest) (*api_v1.AddResponse, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	key, err := idempotencyKey(ctx, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, err
+	}
+	expiry, err := fromExpiry(req.GetExpiry())
+	if err != nil {
+		return nil, err
+	}
+	apply, delta, err := deltaOf(req)
+	if err != nil {
+		return nil, err
+	}
+	number, err := s.mutate(name, key, requestFingerprint(delta, expiry), attributed(ctx, func(number *interfaces.Number) error {
+		setExpiry(number, expiry)
+		return apply(number)
+	}))
+	if err != nil {
+		slog.Error("Error adding to number", "bucket", name, "delta", delta, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	slog.Info("Added to number", "bucket", name, "delta", delta, "number", number.Number)
+	resp := &api_v1.AddResponse{TypedValue: toTypedValue(number)}
+	if number.Type == interfaces.NumberTypeUint64 {
+		resp.Value = number.Number
+	}
+	return resp, nil
+}
+
+// deltaOf picks the delta of an add request
+// - req: *api_v1.AddRequest request
+// Returns the mutation adding the delta and the description of the delta it is fingerprinted by,
+// or InvalidArgument if both deltas are set or the typed delta is not a signed or fractional value
+func deltaOf(req *api_v1.AddRequest) (func(number *interfaces.Number) error, string, error) {
+	if req.TypedDelta == nil {
+		return func(number *interfaces.Number) error {
+			return applyDelta(number, req.GetDelta())
+		}, fmt.Sprintf("add:%d", req.GetDelta()), nil
+	}
+	if req.GetDelta() != 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "delta and typed_delta are mutually exclusive")
+	}
+	switch kind := req.GetTypedDelta().GetKind().(type) {
+	case *api_v1.TypedValue_Int64Value:
+		return func(number *interfaces.Number) error {
+			return applyDelta(number, kind.Int64Value)
+		}, fmt.Sprintf("add:%d", kind.Int64Value), nil
+	case *api_v1.TypedValue_Float64Value:
+		if _, err := fromTypedValue(req.GetTypedDelta()); err != nil {
+			return nil, "", err
+		}
+		return func(number *interfaces.Number) error {
+			return applyFloatDelta(number, kind.Float64Value)
+		}, fmt.Sprintf("add-float:%x", math.Float64bits(kind.Float64Value)), nil
+	case *api_v1.TypedValue_BigValue:
+		delta, err := parseBig(kind.BigValue)
+		if err != nil {
+			return nil, "", err
+		}
+		return func(number *interfaces.Number) error {
+			return applyBigDelta(number, delta)
+		}, fmt.Sprintf("add-big:%d", delta), nil
+	default:
+		return nil, "", status.Error(codes.InvalidArgument, "typed_delta must be an int64, float64 or big integer value")
+	}
+}
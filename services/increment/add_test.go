@@ -0,0 +1,181 @@
+package increment
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdd(t *testing.T) {
+
+	t.Run("adds delta", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "billing").Return(&interfaces.Number{ID: "billing", Number: 10}, nil)
+
+		resp, err := service.Add(context.Background(), &api_v1.AddRequest{Name: "billing", Delta: 32})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(42), resp.Value)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("subtracts delta", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "default").Return(&interfaces.Number{ID: "default", Number: 10}, nil)
+
+		resp, err := service.Add(context.Background(), &api_v1.AddRequest{Delta: -10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), resp.Value)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("underflow is out of range", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "billing").Return(&interfaces.Number{ID: "billing", Number: 1}, nil)
+
+		resp, err := service.Add(context.Background(), &api_v1.AddRequest{Name: "billing", Delta: -2})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("overflow is out of range", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "billing").Return(&interfaces.Number{ID: "billing", Number: math.MaxUint64}, nil)
+
+		resp, err := service.Add(context.Background(), &api_v1.AddRequest{Name: "billing", Delta: 1})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		resp, err := service.Add(context.Background(), &api_v1.AddRequest{Name: "-billing", Delta: 1})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestAddTyped(t *testing.T) {
+	float64Delta := func(delta float64) *api_v1.TypedValue {
+		return &api_v1.TypedValue{Kind: &api_v1.TypedValue_Float64Value{Float64Value: delta}}
+	}
+
+	t.Run("adds a fractional delta to a gauge", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "load").Return(&interfaces.Number{ID: "load", Number: math.Float64bits(1.25), Type: interfaces.NumberTypeFloat64}, nil)
+
+		resp, err := service.Add(context.Background(), &api_v1.AddRequest{Name: "load", TypedDelta: float64Delta(-0.5)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.75, resp.TypedValue.GetFloat64Value())
+		assert.Zero(t, resp.Value)
+	})
+
+	t.Run("int64 counter goes below zero", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "balance").Return(&interfaces.Number{ID: "balance", Number: 2, Type: interfaces.NumberTypeInt64}, nil)
+
+		resp, err := service.Add(context.Background(), &api_v1.AddRequest{Name: "balance", Delta: -5})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-3), resp.TypedValue.GetInt64Value())
+	})
+
+	t.Run("fractional delta on a uint64 counter is a failed precondition", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "billing").Return(&interfaces.Number{ID: "billing", Number: 10}, nil)
+
+		_, err := service.Add(context.Background(), &api_v1.AddRequest{Name: "billing", TypedDelta: float64Delta(0.5)})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	bigDelta := func(delta string) *api_v1.TypedValue {
+		return &api_v1.TypedValue{Kind: &api_v1.TypedValue_BigValue{BigValue: delta}}
+	}
+
+	t.Run("adds a big delta past the uint64 range", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "bytes").Return(&interfaces.Number{ID: "bytes", Big: "18446744073709551615", Type: interfaces.NumberTypeBigInt}, nil)
+
+		resp, err := service.Add(context.Background(), &api_v1.AddRequest{Name: "bytes", TypedDelta: bigDelta("18446744073709551617")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "36893488147419103232", resp.TypedValue.GetBigValue())
+		assert.Zero(t, resp.Value)
+	})
+
+	t.Run("int64 delta on a big integer counter", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "bytes").Return(&interfaces.Number{ID: "bytes", Big: "100", Type: interfaces.NumberTypeBigInt}, nil)
+
+		resp, err := service.Add(context.Background(), &api_v1.AddRequest{Name: "bytes", Delta: -40})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "60", resp.TypedValue.GetBigValue())
+	})
+
+	t.Run("big integer counter below zero is out of range", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "bytes").Return(&interfaces.Number{ID: "bytes", Big: "1", Type: interfaces.NumberTypeBigInt}, nil)
+
+		_, err := service.Add(context.Background(), &api_v1.AddRequest{Name: "bytes", TypedDelta: bigDelta("-2")})
+
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("big delta on a uint64 counter is a failed precondition", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "billing").Return(&interfaces.Number{ID: "billing", Number: 10}, nil)
+
+		_, err := service.Add(context.Background(), &api_v1.AddRequest{Name: "billing", TypedDelta: bigDelta("1")})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	invalid := []struct {
+		name string
+		req  *api_v1.AddRequest
+	}{
+		{name: "both deltas", req: &api_v1.AddRequest{Delta: 1, TypedDelta: float64Delta(0.5)}},
+		{name: "malformed big delta", req: &api_v1.AddRequest{TypedDelta: bigDelta("1e9")}},
+		{name: "unsigned delta", req: &api_v1.AddRequest{TypedDelta: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Uint64Value{Uint64Value: 1}}}},
+		{name: "not finite", req: &api_v1.AddRequest{TypedDelta: float64Delta(math.Inf(1))}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+
+			_, err := service.Add(context.Background(), tt.req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package increment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/bits"
+	"slices"
+	"strings"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// aggregateValue folds a counter value into an aggregation
+// - aggregation: *api_v1.Aggregation aggregation to update
+// - value: uint64 counter value
+// Returns ErrOutOfRange if the sum no longer fits in a uint64
+func aggregateValue(aggregation *api_v1.Aggregation, value uint64) error {
+	sum, carry := bits.Add64(aggregation.Sum, value, 0)
+	if carry != 0 {
+		return fmt.Errorf("%w: sum exceeds the uint64 range", interfaces.ErrOutOfRange)
+	}
+	if aggregation.Count == 0 || value < aggregation.Min {
+		aggregation.Min = value
+	}
+	if aggregation.Count == 0 || value > aggregation.Max {
+		aggregation.Max = value
+	}
+	aggregation.Sum = sum
+	aggregation.Count++
+	return nil
+}
+
+// Aggregate sums the counters matching a label selector, optionally grouped by a label
+// - ctx: context.Context context
+// - req: *api_v1.AggregateRequest request
+// Returns *api_v1.AggregateResponse the aggregations read from a single consistent read, matching
+// counters of other types are left out and counted as skipped, InvalidArgument for a malformed
+// selector or group, or OutOfRange if a sum overflows
+func (s *ServiceImpl) Aggregate(ctx context.Context, req *api_v1.AggregateRequest) (*api_v1.AggregateResponse, error) {
+	selector, err := parseSelector(req.GetSelector())
+	if err != nil {
+		return nil, err
+	}
+	lookup, ok := selector.indexed()
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "selector needs at least one key=value or key requirement")
+	}
+	groupBy := req.GetGroupBy()
+	if groupBy != "" && !labelKeyPattern.MatchString(groupBy) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid group by label %q", groupBy)
+	}
+	var value *string
+	if lookup.operator == selectorEquals {
+		value = &lookup.value
+	}
+
+	resp := &api_v1.AggregateResponse{Total: &api_v1.Aggregation{}}
+	groups := map[string]*api_v1.AggregationGroup{}
+	var unlabeled *api_v1.AggregationGroup
+	err = s.repo.ForEachLabeled(lookup.key, value, func(number interfaces.Number, revision uint64) error {
+		resp.ReadRevision = revision
+		if !selector.matches(number.Labels) {
+			return nil
+		}
+		// only uint64 values add up, a label shared with counters of other types must not
+		// make the selector unusable
+		if number.Type != interfaces.NumberTypeUint64 {
+			resp.Skipped++
+			return nil
+		}
+		if err := aggregateValue(resp.Total, number.Number); err != nil {
+			return err
+		}
+		if groupBy == "" {
+			return nil
+		}
+		label, ok := number.Labels[groupBy]
+		group := groups[label]
+		if !ok {
+			group = unlabeled
+		}
+		if group == nil {
+			group = &api_v1.AggregationGroup{Value: label, Unlabeled: !ok, Aggregation: &api_v1.Aggregation{}}
+			if ok {
+				groups[label] = group
+			} else {
+				unlabeled = group
+			}
+		}
+		return aggregateValue(group.Aggregation, number.Number)
+	})
+	if err != nil {
+		slog.Error("Error aggregating counters", "selector", req.GetSelector(), "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	for _, group := range groups {
+		resp.Groups = append(resp.Groups, group)
+	}
+	slices.SortFunc(resp.Groups, func(a, b *api_v1.AggregationGroup) int {
+		return strings.Compare(a.Value, b.Value)
+	})
+	if unlabeled != nil {
+		resp.Groups = append(resp.Groups, unlabeled)
+	}
+	return resp, nil
+}
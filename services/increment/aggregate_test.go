@@ -0,0 +1,136 @@
+package increment
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAggregate(t *testing.T) {
+	valued := func(value string) interface{} {
+		return mock.MatchedBy(func(v *string) bool { return v != nil && *v == value })
+	}
+	numbers := []interfaces.Number{
+		{ID: "a", Number: 5, Labels: map[string]string{"team": "payments", "env": "prod", "region": "eu"}},
+		{ID: "b", Number: 2, Labels: map[string]string{"team": "payments", "env": "prod", "region": "us"}},
+		{ID: "c", Number: 9, Labels: map[string]string{"team": "payments", "env": "dev", "region": "eu"}},
+		{ID: "d", Number: 7, Labels: map[string]string{"team": "payments", "env": "prod"}},
+		{ID: "e", Number: 1, Labels: map[string]string{"team": "payments", "env": "prod", "region": "eu"}},
+	}
+
+	t.Run("totals matching counters", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ForEachLabeled", "team", valued("payments")).Return(numbers, uint64(12), nil)
+
+		resp, err := service.Aggregate(context.Background(), &api_v1.AggregateRequest{Selector: "team=payments,env!=dev"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &api_v1.Aggregation{Count: 4, Sum: 15, Min: 1, Max: 7}, resp.Total)
+		assert.Empty(t, resp.Groups)
+		assert.Equal(t, uint64(12), resp.ReadRevision)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("groups by a label", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ForEachLabeled", "team", valued("payments")).Return(numbers, uint64(12), nil)
+
+		resp, err := service.Aggregate(context.Background(), &api_v1.AggregateRequest{Selector: "team=payments,env!=dev", GroupBy: "region"})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Groups, 3)
+		assert.Equal(t, "eu", resp.Groups[0].Value)
+		assert.Equal(t, &api_v1.Aggregation{Count: 2, Sum: 6, Min: 1, Max: 5}, resp.Groups[0].Aggregation)
+		assert.Equal(t, "us", resp.Groups[1].Value)
+		assert.Equal(t, uint64(2), resp.Groups[1].Aggregation.Sum)
+		assert.True(t, resp.Groups[2].Unlabeled)
+		assert.Equal(t, uint64(7), resp.Groups[2].Aggregation.Sum)
+		assert.Equal(t, uint64(4), resp.Total.Count)
+	})
+
+	t.Run("existence requirement scans every value", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ForEachLabeled", "region", (*string)(nil)).Return(numbers, uint64(12), nil)
+
+		resp, err := service.Aggregate(context.Background(), &api_v1.AggregateRequest{Selector: "region,env!=dev"})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), resp.Total.Count)
+		assert.Equal(t, uint64(8), resp.Total.Sum)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("counters of other types are skipped", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ForEachLabeled", "team", valued("payments")).Return([]interfaces.Number{
+			{ID: "load", Number: math.Float64bits(0.5), Type: interfaces.NumberTypeFloat64, Labels: map[string]string{"team": "payments", "region": "eu"}},
+			{ID: "visitors", Type: interfaces.NumberTypeDistinct, Labels: map[string]string{"team": "payments", "region": "eu"}},
+			{ID: "orders", Number: 4, Labels: map[string]string{"team": "payments", "region": "eu"}},
+		}, uint64(3), nil)
+
+		resp, err := service.Aggregate(context.Background(), &api_v1.AggregateRequest{Selector: "team=payments", GroupBy: "region"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &api_v1.Aggregation{Count: 1, Sum: 4, Min: 4, Max: 4}, resp.Total)
+		require.Len(t, resp.Groups, 1)
+		assert.Equal(t, uint64(1), resp.Groups[0].Aggregation.Count)
+		assert.Equal(t, uint64(2), resp.Skipped)
+	})
+
+	t.Run("sum overflow is out of range", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ForEachLabeled", "team", valued("payments")).Return([]interfaces.Number{
+			{ID: "a", Number: math.MaxUint64, Labels: map[string]string{"team": "payments"}},
+			{ID: "b", Number: 1, Labels: map[string]string{"team": "payments"}},
+		}, uint64(3), nil)
+
+		_, err := service.Aggregate(context.Background(), &api_v1.AggregateRequest{Selector: "team=payments"})
+
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	invalid := []struct {
+		name string
+		req  *api_v1.AggregateRequest
+	}{
+		{name: "missing selector", req: &api_v1.AggregateRequest{}},
+		{name: "malformed selector", req: &api_v1.AggregateRequest{Selector: "Team=payments"}},
+		{name: "only negative requirements", req: &api_v1.AggregateRequest{Selector: "env!=dev,!legacy"}},
+		{name: "invalid group", req: &api_v1.AggregateRequest{Selector: "team=payments", GroupBy: "Region"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+
+			_, err := service.Aggregate(context.Background(), tt.req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			mockRepo.AssertNotCalled(t, "ForEachLabeled", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("repository error is internal", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ForEachLabeled", "team", valued("payments")).Return(nil, uint64(0), errors.New("disk full"))
+
+		_, err := service.Aggregate(context.Background(), &api_v1.AggregateRequest{Selector: "team=payments"})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
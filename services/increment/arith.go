@@ -0,0 +1,143 @@
+package increment
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+)
+
+// applyDelta adds a signed delta to a number, enforcing the bounds of uint64 numbers, the
+// delta is staged as the change of uint64 numbers so their windows count what was requested
+// - number: *interfaces.Number number to change
+// - delta: int64 amount to add, negative values subtract
+// Returns ErrOutOfRange if the result leaves the bounds and the policy rejects it, or
+// does not fit the type of the number, or ErrTypeMismatch for distinct numbers
+func applyDelta(number *interfaces.Number, delta int64) error {
+	switch number.Type {
+	case interfaces.NumberTypeInt64:
+		current := int64(number.Number)
+		if (delta > 0 && current > math.MaxInt64-delta) || (delta < 0 && current < math.MinInt64-delta) {
+			return fmt.Errorf("%w: %d %+d on %s", interfaces.ErrOutOfRange, current, delta, number.ID)
+		}
+		number.Number = uint64(current + delta)
+		return nil
+	case interfaces.NumberTypeFloat64:
+		return applyFloatDelta(number, float64(delta))
+	case interfaces.NumberTypeBigInt:
+		return applyBigDelta(number, big.NewInt(delta))
+	}
+	if err := checkType(number, interfaces.NumberTypeUint64); err != nil {
+		return err
+	}
+	result := new(big.Int).SetUint64(number.Number)
+	result.Add(result, big.NewInt(delta))
+	value, err := bound(result, number.Bounds)
+	if err != nil {
+		return fmt.Errorf("%w: %d %+d on %s", err, number.Number, delta, number.ID)
+	}
+	number.Number = value
+	number.Change += delta
+	return nil
+}
+
+// applyFloatDelta adds a fractional delta to a float64 number
+// - number: *interfaces.Number number to change
+// - delta: float64 amount to add, negative values subtract
+// Returns ErrTypeMismatch for other types, or ErrOutOfRange if the result is not finite
+func applyFloatDelta(number *interfaces.Number, delta float64) error {
+	if err := checkType(number, interfaces.NumberTypeFloat64); err != nil {
+		return err
+	}
+	current := math.Float64frombits(number.Number)
+	result := current + delta
+	if math.IsInf(result, 0) || math.IsNaN(result) {
+		return fmt.Errorf("%w: %g %+g on %s", interfaces.ErrOutOfRange, current, delta, number.ID)
+	}
+	number.Number = math.Float64bits(result)
+	return nil
+}
+
+// applyBigDelta adds a delta of any size to a big integer number
+// - number: *interfaces.Number number to change
+// - delta: *big.Int amount to add, negative values subtract
+// Returns ErrTypeMismatch for other types, or ErrOutOfRange if the result is negative or has
+// more than maxBigDigits digits
+func applyBigDelta(number *interfaces.Number, delta *big.Int) error {
+	if err := checkType(number, interfaces.NumberTypeBigInt); err != nil {
+		return err
+	}
+	current := bigOf(number)
+	result := new(big.Int).Add(current, delta)
+	if result.Sign() < 0 || len(result.String()) > maxBigDigits {
+		return fmt.Errorf("%w: %d %+d on %s", interfaces.ErrOutOfRange, current, delta, number.ID)
+	}
+	number.Big = result.String()
+	return nil
+}
+
+// applyValue writes an absolute value to a uint64 number, enforcing its bounds
+// - number: *interfaces.Number number to change
+// - value: uint64 value to write
+// Returns ErrTypeMismatch for other types, or ErrOutOfRange if the value is outside the
+// bounds and the policy rejects it
+func applyValue(number *interfaces.Number, value uint64) error {
+	if err := checkType(number, interfaces.NumberTypeUint64); err != nil {
+		return err
+	}
+	bounded, err := bound(new(big.Int).SetUint64(value), number.Bounds)
+	if err != nil {
+		return fmt.Errorf("%w: %d on %s", err, value, number.ID)
+	}
+	number.Number = bounded
+	return nil
+}
+
+// applyTypedValue writes an absolute value of any type to a number
+// - number: *interfaces.Number number to change
+// - value: interfaces.Number holding the value to write and its type
+// Returns ErrTypeMismatch if the number holds another type, or the error of applyValue
+func applyTypedValue(number *interfaces.Number, value interfaces.Number) error {
+	if value.Type == interfaces.NumberTypeUint64 {
+		return applyValue(number, value.Number)
+	}
+	if err := checkType(number, value.Type); err != nil {
+		return err
+	}
+	number.Number = value.Number
+	number.Big = value.Big
+	return nil
+}
+
+// bound fits a result into bounds according to their overflow policy
+// - value: *big.Int unbounded result
+// - bounds: *interfaces.Bounds bounds to enforce, nil rejects results outside of uint64
+// Returns the bounded value or ErrOutOfRange
+func bound(value *big.Int, bounds *interfaces.Bounds) (uint64, error) {
+	limits := interfaces.Bounds{Max: math.MaxUint64}
+	if bounds != nil {
+		limits = *bounds
+	}
+	lo := new(big.Int).SetUint64(limits.Min)
+	hi := new(big.Int).SetUint64(limits.Max)
+	if value.Cmp(lo) >= 0 && value.Cmp(hi) <= 0 {
+		return value.Uint64(), nil
+	}
+	switch limits.Policy {
+	case interfaces.OverflowSaturate:
+		if value.Cmp(lo) < 0 {
+			return limits.Min, nil
+		}
+		return limits.Max, nil
+	case interfaces.OverflowWrap:
+		// lo + (value - lo) mod (hi - lo + 1), Mod is euclidean so the offset is never negative
+		size := new(big.Int).Sub(hi, lo)
+		size.Add(size, big.NewInt(1))
+		offset := new(big.Int).Sub(value, lo)
+		offset.Mod(offset, size)
+		return offset.Add(offset, lo).Uint64(), nil
+	default:
+		return 0, fmt.Errorf("%w: outside [%d, %d]", interfaces.ErrOutOfRange, limits.Min, limits.Max)
+	}
+}
@@ -0,0 +1,198 @@
+package increment
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDelta(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    uint64
+		delta    int64
+		bounds   *interfaces.Bounds
+		expected uint64
+		wantErr  bool
+	}{
+		{name: "Positive", value: 10, delta: 5, expected: 15},
+		{name: "Negative", value: 10, delta: -4, expected: 6},
+		{name: "Zero", value: 10, delta: 0, expected: 10},
+		{name: "ToZero", value: 10, delta: -10, expected: 0},
+		{name: "ToMax", value: math.MaxUint64 - 1, delta: 1, expected: math.MaxUint64},
+		{name: "Overflow", value: math.MaxUint64, delta: 1, wantErr: true},
+		{name: "Underflow", value: 3, delta: -4, wantErr: true},
+		{name: "MinInt64", value: 1 << 63, delta: math.MinInt64, expected: 0},
+		{name: "MinInt64Underflow", value: 1<<63 - 1, delta: math.MinInt64, wantErr: true},
+		{name: "RejectAboveMax", value: 9, delta: 2, bounds: &interfaces.Bounds{Min: 5, Max: 10}, wantErr: true},
+		{name: "RejectBelowMin", value: 6, delta: -2, bounds: &interfaces.Bounds{Min: 5, Max: 10}, wantErr: true},
+		{name: "RejectWithin", value: 6, delta: 4, bounds: &interfaces.Bounds{Min: 5, Max: 10}, expected: 10},
+		{name: "SaturateAboveMax", value: 9, delta: 100, bounds: &interfaces.Bounds{Min: 5, Max: 10, Policy: interfaces.OverflowSaturate}, expected: 10},
+		{name: "SaturateBelowMin", value: 6, delta: -100, bounds: &interfaces.Bounds{Min: 5, Max: 10, Policy: interfaces.OverflowSaturate}, expected: 5},
+		{name: "SaturateUint64", value: math.MaxUint64, delta: 1, bounds: &interfaces.Bounds{Max: math.MaxUint64, Policy: interfaces.OverflowSaturate}, expected: math.MaxUint64},
+		{name: "WrapAboveMax", value: 9, delta: 3, bounds: &interfaces.Bounds{Min: 5, Max: 10, Policy: interfaces.OverflowWrap}, expected: 6},
+		{name: "WrapBelowMin", value: 6, delta: -2, bounds: &interfaces.Bounds{Min: 5, Max: 10, Policy: interfaces.OverflowWrap}, expected: 10},
+		{name: "WrapMultipleTimes", value: 5, delta: 13, bounds: &interfaces.Bounds{Min: 5, Max: 10, Policy: interfaces.OverflowWrap}, expected: 6},
+		{name: "WrapUint64", value: math.MaxUint64, delta: 1, bounds: &interfaces.Bounds{Max: math.MaxUint64, Policy: interfaces.OverflowWrap}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number := &interfaces.Number{ID: "counter", Number: tt.value, Bounds: tt.bounds}
+			err := applyDelta(number, tt.delta)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, interfaces.ErrOutOfRange)
+				assert.Equal(t, tt.value, number.Number)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, number.Number)
+			}
+		})
+	}
+}
+
+func TestApplyValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    uint64
+		bounds   *interfaces.Bounds
+		expected uint64
+		wantErr  bool
+	}{
+		{name: "Unbounded", value: math.MaxUint64, expected: math.MaxUint64},
+		{name: "Within", value: 7, bounds: &interfaces.Bounds{Min: 5, Max: 10}, expected: 7},
+		{name: "Reject", value: 11, bounds: &interfaces.Bounds{Min: 5, Max: 10}, wantErr: true},
+		{name: "Saturate", value: 2, bounds: &interfaces.Bounds{Min: 5, Max: 10, Policy: interfaces.OverflowSaturate}, expected: 5},
+		{name: "Wrap", value: 12, bounds: &interfaces.Bounds{Min: 5, Max: 10, Policy: interfaces.OverflowWrap}, expected: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number := &interfaces.Number{ID: "counter", Number: 5, Bounds: tt.bounds}
+			err := applyValue(number, tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, interfaces.ErrOutOfRange)
+				assert.Equal(t, uint64(5), number.Number)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, number.Number)
+			}
+		})
+	}
+}
+
+func TestApplyDeltaTyped(t *testing.T) {
+	signed := func(v int64) uint64 { return uint64(v) }
+	tests := []struct {
+		name       string
+		numberType interfaces.NumberType
+		value      uint64
+		delta      int64
+		expected   uint64
+		wantErr    bool
+	}{
+		{name: "Int64BelowZero", numberType: interfaces.NumberTypeInt64, value: signed(3), delta: -5, expected: signed(-2)},
+		{name: "Int64Up", numberType: interfaces.NumberTypeInt64, value: signed(-2), delta: 7, expected: signed(5)},
+		{name: "Int64Overflow", numberType: interfaces.NumberTypeInt64, value: signed(math.MaxInt64), delta: 1, wantErr: true},
+		{name: "Int64Underflow", numberType: interfaces.NumberTypeInt64, value: signed(math.MinInt64), delta: -1, wantErr: true},
+		{name: "Float64", numberType: interfaces.NumberTypeFloat64, value: math.Float64bits(1.5), delta: -2, expected: math.Float64bits(-0.5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number := &interfaces.Number{ID: "counter", Number: tt.value, Type: tt.numberType}
+			err := applyDelta(number, tt.delta)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, interfaces.ErrOutOfRange)
+				assert.Equal(t, tt.value, number.Number)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, number.Number)
+			}
+		})
+	}
+}
+
+func TestApplyFloatDelta(t *testing.T) {
+	a, b := 0.1, 0.2
+	number := &interfaces.Number{ID: "gauge", Number: math.Float64bits(a), Type: interfaces.NumberTypeFloat64}
+	assert.NoError(t, applyFloatDelta(number, b))
+	assert.Equal(t, a+b, math.Float64frombits(number.Number))
+
+	number = &interfaces.Number{ID: "gauge", Number: math.Float64bits(math.MaxFloat64), Type: interfaces.NumberTypeFloat64}
+	assert.ErrorIs(t, applyFloatDelta(number, math.MaxFloat64), interfaces.ErrOutOfRange)
+
+	number = &interfaces.Number{ID: "counter", Number: 5}
+	assert.ErrorIs(t, applyFloatDelta(number, 0.5), interfaces.ErrTypeMismatch)
+	assert.Equal(t, uint64(5), number.Number)
+}
+
+func TestApplyTypedValue(t *testing.T) {
+	number := &interfaces.Number{ID: "balance", Type: interfaces.NumberTypeInt64}
+	assert.NoError(t, applyTypedValue(number, interfaces.Number{Number: 1<<64 - 3, Type: interfaces.NumberTypeInt64}))
+	assert.Equal(t, int64(-3), int64(number.Number))
+
+	assert.ErrorIs(t, applyTypedValue(number, interfaces.Number{Number: 7}), interfaces.ErrTypeMismatch)
+	assert.ErrorIs(t, applyTypedValue(number, interfaces.Number{Number: math.Float64bits(1), Type: interfaces.NumberTypeFloat64}), interfaces.ErrTypeMismatch)
+	assert.ErrorIs(t, applyTypedValue(number, interfaces.Number{Big: "1", Type: interfaces.NumberTypeBigInt}), interfaces.ErrTypeMismatch)
+	assert.Equal(t, int64(-3), int64(number.Number))
+
+	number = &interfaces.Number{ID: "seats", Bounds: &interfaces.Bounds{Min: 5, Max: 10}}
+	assert.ErrorIs(t, applyTypedValue(number, interfaces.Number{Number: 11}), interfaces.ErrOutOfRange)
+
+	number = &interfaces.Number{ID: "bytes", Type: interfaces.NumberTypeBigInt}
+	assert.NoError(t, applyTypedValue(number, interfaces.Number{Big: "18446744073709551616", Type: interfaces.NumberTypeBigInt}))
+	assert.Equal(t, "18446744073709551616", number.Big)
+}
+
+func TestApplyBigDelta(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		delta    string
+		expected string
+		wantErr  error
+	}{
+		{name: "PastUint64", value: "18446744073709551615", delta: "1", expected: "18446744073709551616"},
+		{name: "FromEmpty", value: "", delta: "42", expected: "42"},
+		{name: "Subtract", value: "18446744073709551616", delta: "-18446744073709551616", expected: "0"},
+		{name: "BelowZero", value: "5", delta: "-6", wantErr: interfaces.ErrOutOfRange},
+		{name: "TooManyDigits", value: strings.Repeat("9", maxBigDigits), delta: "1", wantErr: interfaces.ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number := &interfaces.Number{ID: "bytes", Big: tt.value, Type: interfaces.NumberTypeBigInt}
+			delta, ok := new(big.Int).SetString(tt.delta, 10)
+			require.True(t, ok)
+			err := applyBigDelta(number, delta)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.value, number.Big)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, number.Big)
+			}
+		})
+	}
+
+	number := &interfaces.Number{ID: "bytes", Big: "10", Type: interfaces.NumberTypeBigInt}
+	assert.NoError(t, applyDelta(number, -3))
+	assert.Equal(t, "7", number.Big)
+	assert.ErrorIs(t, applyDelta(number, -8), interfaces.ErrOutOfRange)
+
+	number = &interfaces.Number{ID: "counter", Number: 5}
+	assert.ErrorIs(t, applyBigDelta(number, big.NewInt(1)), interfaces.ErrTypeMismatch)
+}
+
+func TestApplyDeltaDistinct(t *testing.T) {
+	number := &interfaces.Number{ID: "visitors", Number: 5, Type: interfaces.NumberTypeDistinct}
+	assert.ErrorIs(t, applyDelta(number, 1), interfaces.ErrTypeMismatch)
+	assert.ErrorIs(t, applyValue(number, 1), interfaces.ErrTypeMismatch)
+	assert.Equal(t, uint64(5), number.Number)
+}
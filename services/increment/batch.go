@@ -0,0 +1,135 @@
+package increment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxBatchMutations caps how many mutations a single batch can hold
+	maxBatchMutations = 1000
+	// batchErrorReason is the ErrorInfo reason attached when a mutation fails
+	batchErrorReason = "MUTATION_FAILED"
+	// errorDomain is the ErrorInfo domain of errors raised by this service
+	errorDomain = "api.v1.IncrementService"
+)
+
+// mutationError records which mutation of a batch failed
+type mutationError struct {
+	index int
+	name  string
+	err   error
+}
+
+// Error implements error
+func (e *mutationError) Error() string {
+	return fmt.Sprintf("mutation %d (%s): %v", e.index, e.name, e.err)
+}
+
+// Unwrap returns the cause of the failure
+func (e *mutationError) Unwrap() error {
+	return e.err
+}
+
+// status converts the failure into a status carrying the mutation index and name
+// Returns a status error with the code of the cause and an ErrorInfo detail
+func (e *mutationError) status() error {
+	cause := status.Convert(rpc.ToStatus(e.err))
+	st := status.New(cause.Code(), fmt.Sprintf("mutation %d (%s): %s", e.index, e.name, cause.Message()))
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: batchErrorReason,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"index": strconv.Itoa(e.index),
+			"name":  e.name,
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// applyMutation applies a single mutation to a number
+// - number: *interfaces.Number number to change
+// - mutation: *api_v1.Mutation mutation to apply
+// Returns an error if a precondition does not hold or the result is out of range
+func applyMutation(number *interfaces.Number, mutation *api_v1.Mutation) error {
+	if err := checkPreconditions(number, mutation.ExpectedValue, mutation.ExpectedVersion); err != nil {
+		return err
+	}
+	switch operation := mutation.GetOperation().(type) {
+	case *api_v1.Mutation_Increment:
+		return applyDelta(number, 1)
+	case *api_v1.Mutation_Add:
+		return applyDelta(number, operation.Add)
+	case *api_v1.Mutation_Set:
+		return applyValue(number, operation.Set)
+	default:
+		return status.Error(codes.InvalidArgument, "mutation has no operation")
+	}
+}
+
+// BatchMutate applies every mutation in a single transaction, all or nothing
+// - ctx: context.Context context
+// - req: *api_v1.BatchMutateRequest request
+// Returns *api_v1.BatchMutateResponse the counters after each mutation in request order, or
+// the error of the first failing mutation with its index in an ErrorInfo detail
+func (s *ServiceImpl) BatchMutate(ctx context.Context, req *api_v1.BatchMutateRequest) (*api_v1.BatchMutateResponse, error) {
+	mutations := req.GetMutations()
+	if len(mutations) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "batch has no mutations")
+	}
+	if len(mutations) > maxBatchMutations {
+		return nil, status.Errorf(codes.InvalidArgument, "batch has %d mutations, at most %d are allowed", len(mutations), maxBatchMutations)
+	}
+	names := make([]string, len(mutations))
+	for i, mutation := range mutations {
+		name, err := s.resolveName(mutation.GetName())
+		if err != nil {
+			return nil, (&mutationError{index: i, name: mutation.GetName(), err: err}).status()
+		}
+		names[i] = name
+	}
+
+	source := changeSource(ctx)
+	var counters []*api_v1.Counter
+	err := s.repo.Transact(func(txn interfaces.INumberTxn) error {
+		counters = make([]*api_v1.Counter, 0, len(mutations))
+		for i, mutation := range mutations {
+			number, err := txn.Get(names[i])
+			if err != nil {
+				return err
+			}
+			if err := applyMutation(number, mutation); err != nil {
+				return &mutationError{index: i, name: names[i], err: err}
+			}
+			number.Source = source
+			if err := txn.Put(number); err != nil {
+				return err
+			}
+			counters = append(counters, toCounter(number))
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Warn("Error applying batch", "mutations", len(mutations), "error", err)
+		var failed *mutationError
+		if errors.As(err, &failed) {
+			return nil, failed.status()
+		}
+		return nil, rpc.ToStatus(err)
+	}
+	slog.Info("Applied batch", "mutations", len(mutations))
+	return &api_v1.BatchMutateResponse{Counters: counters}, nil
+}
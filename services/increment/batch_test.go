@@ -0,0 +1,108 @@
+package increment
+
+import (
+	"context"
+	"testing"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestBatchMutate(t *testing.T) {
+
+	t.Run("applies mutations in order", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		stored := map[string]interfaces.Number{
+			"warehouse/a": {ID: "warehouse/a", Number: 10, Version: 1},
+		}
+		mockRepo.On("Transact").Return(stored, nil)
+
+		resp, err := service.BatchMutate(context.Background(), &api_v1.BatchMutateRequest{Mutations: []*api_v1.Mutation{
+			{Name: "warehouse/a", Operation: &api_v1.Mutation_Add{Add: -3}, ExpectedValue: proto.Uint64(10)},
+			{Name: "warehouse/b", Operation: &api_v1.Mutation_Add{Add: 3}},
+			{Name: "warehouse/b", Operation: &api_v1.Mutation_Increment{Increment: &emptypb.Empty{}}},
+			{Name: "audit", Operation: &api_v1.Mutation_Set{Set: 99}},
+		}})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Counters, 4)
+		assert.Equal(t, uint64(7), resp.Counters[0].Value)
+		assert.Equal(t, uint64(3), resp.Counters[1].Value)
+		assert.Equal(t, uint64(4), resp.Counters[2].Value)
+		assert.Equal(t, "audit", resp.Counters[3].Name)
+		assert.Equal(t, uint64(99), resp.Counters[3].Value)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("failing mutation reports its index", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		stored := map[string]interfaces.Number{
+			"warehouse/a": {ID: "warehouse/a", Number: 2, Version: 1},
+		}
+		mockRepo.On("Transact").Return(stored, nil)
+
+		resp, err := service.BatchMutate(context.Background(), &api_v1.BatchMutateRequest{Mutations: []*api_v1.Mutation{
+			{Name: "warehouse/b", Operation: &api_v1.Mutation_Add{Add: 5}},
+			{Name: "warehouse/a", Operation: &api_v1.Mutation_Add{Add: -5}},
+		}})
+
+		assert.Nil(t, resp)
+		st := status.Convert(err)
+		assert.Equal(t, codes.OutOfRange, st.Code())
+		assert.Contains(t, st.Message(), "mutation 1 (warehouse/a)")
+		require.Len(t, st.Details(), 1)
+		info := st.Details()[0].(*errdetails.ErrorInfo)
+		assert.Equal(t, batchErrorReason, info.Reason)
+		assert.Equal(t, "1", info.Metadata["index"])
+		assert.Equal(t, "warehouse/a", info.Metadata["name"])
+	})
+
+	t.Run("precondition failure aborts the batch", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		stored := map[string]interfaces.Number{
+			"warehouse/a": {ID: "warehouse/a", Number: 2, Version: 4},
+		}
+		mockRepo.On("Transact").Return(stored, nil)
+
+		_, err := service.BatchMutate(context.Background(), &api_v1.BatchMutateRequest{Mutations: []*api_v1.Mutation{
+			{Name: "warehouse/a", Operation: &api_v1.Mutation_Set{Set: 1}, ExpectedVersion: proto.Uint64(3)},
+		}})
+
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		service := NewIncrementService(new(MockNumberRepository), "default")
+
+		requests := []*api_v1.BatchMutateRequest{
+			{},
+			{Mutations: []*api_v1.Mutation{{Name: "bad name", Operation: &api_v1.Mutation_Set{Set: 1}}}},
+			{Mutations: make([]*api_v1.Mutation, maxBatchMutations+1)},
+		}
+		for _, req := range requests {
+			resp, err := service.BatchMutate(context.Background(), req)
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	t.Run("missing operation", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Transact").Return(map[string]interfaces.Number{}, nil)
+
+		_, err := service.BatchMutate(context.Background(), &api_v1.BatchMutateRequest{Mutations: []*api_v1.Mutation{{Name: "a"}}})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,68 @@
+package increment
+
+import (
+	"context"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// callerIDMetadataKey is the metadata key clients without a client certificate can identify themselves with
+	callerIDMetadataKey = "x-caller-id"
+	// requestIDMetadataKey is the metadata key clients can tag a request with for the counter history
+	requestIDMetadataKey = "x-request-id"
+	// maxRequestIDLength caps the size of request IDs recorded in the counter history
+	maxRequestIDLength = 128
+)
+
+// callerIdentity describes who issued a request, for audit logging
+// - ctx: context.Context request context
+// Returns the client certificate common name when mTLS is used, otherwise the
+// self reported x-caller-id metadata value, otherwise the peer address
+func callerIdentity(ctx context.Context) string {
+	p, hasPeer := peer.FromContext(ctx)
+	if hasPeer {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			return tlsInfo.State.PeerCertificates[0].Subject.CommonName
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(callerIDMetadataKey); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	if hasPeer && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return "unknown"
+}
+
+// changeSource describes who issued a request, for the counter history
+// - ctx: context.Context request context
+// Returns interfaces.ChangeSource the caller identity and the x-request-id metadata value,
+// request IDs longer than maxRequestIDLength are truncated
+func changeSource(ctx context.Context) interfaces.ChangeSource {
+	source := interfaces.ChangeSource{Caller: callerIdentity(ctx)}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadataKey); len(values) > 0 {
+			source.RequestID = values[0][:min(len(values[0]), maxRequestIDLength)]
+		}
+	}
+	return source
+}
+
+// attributed wraps a mutation so the write it makes is credited to the caller in the counter history
+// - ctx: context.Context request context
+// - fn: func(*interfaces.Number) error mutation to wrap
+// Returns func(*interfaces.Number) error the wrapped mutation
+func attributed(ctx context.Context, fn func(number *interfaces.Number) error) func(number *interfaces.Number) error {
+	source := changeSource(ctx)
+	return func(number *interfaces.Number) error {
+		number.Source = source
+		return fn(number)
+	}
+}
@@ -0,0 +1,96 @@
+package increment
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestCallerIdentity(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 7), Port: 4242}
+	certInfo := credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "billing-job"}}},
+	}}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{
+			name:     "NoPeer",
+			ctx:      context.Background(),
+			expected: "unknown",
+		},
+		{
+			name:     "PeerAddress",
+			ctx:      peer.NewContext(context.Background(), &peer.Peer{Addr: addr}),
+			expected: "10.0.0.7:4242",
+		},
+		{
+			name: "Metadata",
+			ctx: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{Addr: addr}),
+				metadata.Pairs(callerIDMetadataKey, "operator-jane"),
+			),
+			expected: "operator-jane",
+		},
+		{
+			name: "ClientCertificate",
+			ctx: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: certInfo}),
+				metadata.Pairs(callerIDMetadataKey, "operator-jane"),
+			),
+			expected: "billing-job",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, callerIdentity(tt.ctx))
+		})
+	}
+}
+
+func TestChangeSource(t *testing.T) {
+	t.Run("records caller and request ID", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(callerIDMetadataKey, "operator-jane", requestIDMetadataKey, "req-1"))
+
+		source := changeSource(ctx)
+
+		assert.Equal(t, interfaces.ChangeSource{Caller: "operator-jane", RequestID: "req-1"}, source)
+	})
+
+	t.Run("request ID is optional", func(t *testing.T) {
+		assert.Equal(t, interfaces.ChangeSource{Caller: "unknown"}, changeSource(context.Background()))
+	})
+
+	t.Run("long request IDs are truncated", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDMetadataKey, strings.Repeat("r", maxRequestIDLength+10)))
+
+		assert.Len(t, changeSource(ctx).RequestID, maxRequestIDLength)
+	})
+
+	t.Run("attributed sets the source before the mutation", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(callerIDMetadataKey, "operator-jane"))
+		number := &interfaces.Number{ID: "orders"}
+
+		err := attributed(ctx, func(number *interfaces.Number) error {
+			assert.Equal(t, "operator-jane", number.Source.Caller)
+			return nil
+		})(number)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "operator-jane", number.Source.Caller)
+	})
+}
@@ -0,0 +1,82 @@
+package increment
+
+import (
+	"math"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toCounter converts a stored number into its API representation
+// - number: *interfaces.Number stored number
+// Returns *api_v1.Counter counter message
+func toCounter(number *interfaces.Number) *api_v1.Counter {
+	counter := &api_v1.Counter{
+		Name:         number.ID,
+		Version:      number.Version,
+		UpdateTime:   timestamppb.New(number.UpdatedAt),
+		Bounds:       toBounds(number.Bounds),
+		Expiry:       toExpiry(number.Expiry),
+		RemainingTtl: remainingTTL(number),
+		Windows:      toWindows(number.Windows),
+		Labels:       number.Labels,
+		Description:  number.Description,
+		Owner:        number.Owner,
+		Type:         toCounterType(number.Type),
+		TypedValue:   toTypedValue(number),
+	}
+	if number.Type == interfaces.NumberTypeUint64 {
+		counter.Value = number.Number
+	}
+	if !number.CreatedAt.IsZero() {
+		counter.CreateTime = timestamppb.New(number.CreatedAt)
+	}
+	return counter
+}
+
+// toBounds converts stored bounds into their API representation
+// - bounds: *interfaces.Bounds stored bounds
+// Returns *api_v1.Bounds bounds message, nil for unbounded numbers
+func toBounds(bounds *interfaces.Bounds) *api_v1.Bounds {
+	if bounds == nil {
+		return nil
+	}
+	policy := api_v1.OverflowPolicy_OVERFLOW_POLICY_REJECT
+	switch bounds.Policy {
+	case interfaces.OverflowSaturate:
+		policy = api_v1.OverflowPolicy_OVERFLOW_POLICY_SATURATE
+	case interfaces.OverflowWrap:
+		policy = api_v1.OverflowPolicy_OVERFLOW_POLICY_WRAP
+	}
+	return &api_v1.Bounds{Min: bounds.Min, Max: &bounds.Max, Policy: policy}
+}
+
+// fromBounds converts requested bounds into their stored form
+// - bounds: *api_v1.Bounds requested bounds, nil for an unbounded number
+// Returns *interfaces.Bounds stored bounds, or InvalidArgument if they are not valid
+func fromBounds(bounds *api_v1.Bounds) (*interfaces.Bounds, error) {
+	if bounds == nil {
+		return nil, nil
+	}
+	result := &interfaces.Bounds{Min: bounds.GetMin(), Max: math.MaxUint64}
+	if bounds.Max != nil {
+		result.Max = bounds.GetMax()
+	}
+	if result.Min > result.Max {
+		return nil, status.Errorf(codes.InvalidArgument, "bounds min %d is above max %d", result.Min, result.Max)
+	}
+	switch bounds.GetPolicy() {
+	case api_v1.OverflowPolicy_OVERFLOW_POLICY_UNSPECIFIED, api_v1.OverflowPolicy_OVERFLOW_POLICY_REJECT:
+		result.Policy = interfaces.OverflowReject
+	case api_v1.OverflowPolicy_OVERFLOW_POLICY_SATURATE:
+		result.Policy = interfaces.OverflowSaturate
+	case api_v1.OverflowPolicy_OVERFLOW_POLICY_WRAP:
+		result.Policy = interfaces.OverflowWrap
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown overflow policy %v", bounds.GetPolicy())
+	}
+	return result, nil
+}
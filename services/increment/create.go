@@ -0,0 +1,95 @@
+package increment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateCounter creates a counter with its settings, such as type, bounds, expiry, time windows and metadata
+// - ctx: context.Context context
+// - req: *api_v1.CreateCounterRequest request
+// Returns *api_v1.Counter the created counter, AlreadyExists if it exists, or
+// InvalidArgument if the settings are not valid
+func (s *ServiceImpl) CreateCounter(ctx context.Context, req *api_v1.CreateCounterRequest) (*api_v1.Counter, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	bounds, err := fromBounds(req.GetBounds())
+	if err != nil {
+		return nil, err
+	}
+	expiry, err := fromExpiry(req.GetExpiry())
+	if err != nil {
+		return nil, err
+	}
+	windows, err := fromWindows(req.GetWindows())
+	if err != nil {
+		return nil, err
+	}
+	md, err := fromMetadata(req.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+	numberType, err := fromCounterType(req.GetType())
+	if err != nil {
+		return nil, err
+	}
+	if numberType != interfaces.NumberTypeUint64 && (bounds != nil || len(windows) > 0) {
+		return nil, status.Error(codes.InvalidArgument, "bounds and windows are only supported by uint64 counters")
+	}
+	initial := interfaces.Number{Number: req.GetInitialValue(), Type: numberType}
+	if req.TypedInitialValue != nil {
+		initial, err = fromTypedValue(req.GetTypedInitialValue())
+		if err != nil {
+			return nil, err
+		}
+		if req.GetInitialValue() != 0 || initial.Type != numberType {
+			return nil, status.Errorf(codes.InvalidArgument, "typed initial value must be the only initial value and a %s value", typeNames[numberType])
+		}
+	} else if numberType != interfaces.NumberTypeUint64 && initial.Number != 0 {
+		return nil, status.Error(codes.InvalidArgument, "initial_value only applies to uint64 counters")
+	}
+	precision := s.distinctPrecision
+	if req.GetDistinctPrecision() != 0 {
+		if numberType != interfaces.NumberTypeDistinct {
+			return nil, status.Error(codes.InvalidArgument, "distinct_precision only applies to distinct counters")
+		}
+		if req.GetDistinctPrecision() < minDistinctPrecision || req.GetDistinctPrecision() > maxDistinctPrecision {
+			return nil, status.Errorf(codes.InvalidArgument, "distinct precision %d is outside [%d, %d]", req.GetDistinctPrecision(), minDistinctPrecision, maxDistinctPrecision)
+		}
+		precision = uint8(req.GetDistinctPrecision())
+	}
+	if bounds != nil && (initial.Number < bounds.Min || initial.Number > bounds.Max) {
+		return nil, status.Errorf(codes.InvalidArgument, "initial value %d is outside [%d, %d]", initial.Number, bounds.Min, bounds.Max)
+	}
+	number, err := s.repo.Update(name, attributed(ctx, func(number *interfaces.Number) error {
+		if number.Version != 0 {
+			return fmt.Errorf("%w: %s", interfaces.ErrAlreadyExists, name)
+		}
+		number.Type = numberType
+		number.Number = initial.Number
+		number.Big = initial.Big
+		if numberType == interfaces.NumberTypeDistinct {
+			storeSketch(number, newSketch(precision))
+		}
+		number.Bounds = bounds
+		setExpiry(number, expiry)
+		number.Windows = windows
+		md.apply(number, nil)
+		return nil
+	}))
+	if err != nil {
+		slog.Warn("Error creating counter", "bucket", name, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	slog.Info("Created counter", "bucket", name, "caller", callerIdentity(ctx), "number", number.Number)
+	return toCounter(number), nil
+}
@@ -0,0 +1,260 @@
+package increment
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCreateCounter(t *testing.T) {
+
+	t.Run("creates bounded counter", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "seats").Return(&interfaces.Number{ID: "seats"}, nil)
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{
+			Name:         "seats",
+			InitialValue: 5,
+			Bounds:       &api_v1.Bounds{Min: 1, Max: proto.Uint64(10), Policy: api_v1.OverflowPolicy_OVERFLOW_POLICY_SATURATE},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), counter.Value)
+		assert.Equal(t, uint64(1), counter.Bounds.GetMin())
+		assert.Equal(t, uint64(10), counter.Bounds.GetMax())
+		assert.Equal(t, api_v1.OverflowPolicy_OVERFLOW_POLICY_SATURATE, counter.Bounds.GetPolicy())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unset max and policy use defaults", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "seats").Return(&interfaces.Number{ID: "seats"}, nil)
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{Name: "seats", Bounds: &api_v1.Bounds{}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(math.MaxUint64), counter.Bounds.GetMax())
+		assert.Equal(t, api_v1.OverflowPolicy_OVERFLOW_POLICY_REJECT, counter.Bounds.GetPolicy())
+	})
+
+	t.Run("unbounded counter has no bounds", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "default").Return(&interfaces.Number{ID: "default"}, nil)
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{InitialValue: 7})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7), counter.Value)
+		assert.Nil(t, counter.Bounds)
+	})
+
+	t.Run("creates windowed counter", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		stored := &interfaces.Number{ID: "hits"}
+		mockRepo.On("Update", "hits").Return(stored, nil)
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{
+			Name:    "hits",
+			Windows: []*api_v1.Window{{Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_MINUTE}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []interfaces.Window{{Resolution: time.Minute, Retention: 24 * time.Hour}}, stored.Windows)
+		assert.Len(t, counter.Windows, 1)
+		assert.Equal(t, api_v1.WindowResolution_WINDOW_RESOLUTION_MINUTE, counter.Windows[0].Resolution)
+	})
+
+	t.Run("creates counter with metadata", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "orders").Return(&interfaces.Number{ID: "orders"}, nil)
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{
+			Name:     "orders",
+			Metadata: &api_v1.CounterMetadata{Labels: map[string]string{"team": "payments"}, Description: "orders placed", Owner: "alice"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"team": "payments"}, counter.Labels)
+		assert.Equal(t, "orders placed", counter.Description)
+		assert.Equal(t, "alice", counter.Owner)
+		assert.Nil(t, counter.CreateTime)
+	})
+
+	t.Run("invalid metadata is rejected", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		_, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{
+			Name:     "orders",
+			Metadata: &api_v1.CounterMetadata{Owner: strings.Repeat("x", maxOwnerLength+1)},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockRepo.AssertNotCalled(t, "Update", "orders")
+	})
+
+	t.Run("existing counter already exists", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "seats").Return(&interfaces.Number{ID: "seats", Number: 2, Version: 1}, nil)
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{Name: "seats"})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("counter saved before versions were tracked already exists", func(t *testing.T) {
+		repo := legacyRepository(t, map[string]uint64{"seats": 500})
+		service := NewIncrementService(repo, "default")
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{Name: "seats", InitialValue: 1})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		stored, err := repo.FindByID("seats")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(500), stored.Number)
+	})
+
+	t.Run("min above max is invalid", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{
+			Name:   "seats",
+			Bounds: &api_v1.Bounds{Min: 10, Max: proto.Uint64(1)},
+		})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockRepo.AssertNotCalled(t, "Update", "seats")
+	})
+
+	t.Run("initial value outside bounds is invalid", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{
+			Name:         "seats",
+			InitialValue: 11,
+			Bounds:       &api_v1.Bounds{Max: proto.Uint64(10)},
+		})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestCreateCounterTyped(t *testing.T) {
+	t.Run("creates a gauge", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "load").Return(&interfaces.Number{ID: "load"}, nil)
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{
+			Name:              "load",
+			Type:              api_v1.CounterType_COUNTER_TYPE_FLOAT64,
+			TypedInitialValue: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Float64Value{Float64Value: 0.25}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, api_v1.CounterType_COUNTER_TYPE_FLOAT64, counter.Type)
+		assert.Equal(t, 0.25, counter.TypedValue.GetFloat64Value())
+	})
+
+	t.Run("creates a big integer counter", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "bytes").Return(&interfaces.Number{ID: "bytes"}, nil)
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{
+			Name:              "bytes",
+			Type:              api_v1.CounterType_COUNTER_TYPE_BIG_INT,
+			TypedInitialValue: &api_v1.TypedValue{Kind: &api_v1.TypedValue_BigValue{BigValue: "100000000000000000000"}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, api_v1.CounterType_COUNTER_TYPE_BIG_INT, counter.Type)
+		assert.Equal(t, "100000000000000000000", counter.TypedValue.GetBigValue())
+	})
+
+	t.Run("creates a distinct counter with its precision", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		number := &interfaces.Number{ID: "visitors"}
+		mockRepo.On("Update", "visitors").Return(number, nil)
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{
+			Name:              "visitors",
+			Type:              api_v1.CounterType_COUNTER_TYPE_DISTINCT,
+			DistinctPrecision: 8,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, api_v1.CounterType_COUNTER_TYPE_DISTINCT, counter.Type)
+		assert.Equal(t, uint64(0), counter.TypedValue.GetUint64Value())
+		assert.Equal(t, newSketch(8).encode(), number.Sketch)
+	})
+
+	t.Run("untyped counters are uint64 counters", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "orders").Return(&interfaces.Number{ID: "orders"}, nil)
+
+		counter, err := service.CreateCounter(context.Background(), &api_v1.CreateCounterRequest{Name: "orders", InitialValue: 3})
+
+		assert.NoError(t, err)
+		assert.Equal(t, api_v1.CounterType_COUNTER_TYPE_UINT64, counter.Type)
+		assert.Equal(t, uint64(3), counter.TypedValue.GetUint64Value())
+	})
+
+	invalid := []struct {
+		name string
+		req  *api_v1.CreateCounterRequest
+	}{
+		{name: "unknown type", req: &api_v1.CreateCounterRequest{Type: api_v1.CounterType(9)}},
+		{name: "bounded int64", req: &api_v1.CreateCounterRequest{Type: api_v1.CounterType_COUNTER_TYPE_INT64, Bounds: &api_v1.Bounds{}}},
+		{name: "windowed gauge", req: &api_v1.CreateCounterRequest{
+			Type:    api_v1.CounterType_COUNTER_TYPE_FLOAT64,
+			Windows: []*api_v1.Window{{Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_MINUTE}},
+		}},
+		{name: "initial value of another type", req: &api_v1.CreateCounterRequest{
+			Type:              api_v1.CounterType_COUNTER_TYPE_INT64,
+			TypedInitialValue: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Float64Value{Float64Value: 1}},
+		}},
+		{name: "distinct precision out of range", req: &api_v1.CreateCounterRequest{Type: api_v1.CounterType_COUNTER_TYPE_DISTINCT, DistinctPrecision: maxDistinctPrecision + 1}},
+		{name: "distinct precision of a uint64", req: &api_v1.CreateCounterRequest{DistinctPrecision: 10}},
+		{name: "initial value of a distinct counter", req: &api_v1.CreateCounterRequest{
+			Type:              api_v1.CounterType_COUNTER_TYPE_DISTINCT,
+			TypedInitialValue: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Uint64Value{Uint64Value: 1}},
+		}},
+		{name: "bounded big integer", req: &api_v1.CreateCounterRequest{Type: api_v1.CounterType_COUNTER_TYPE_BIG_INT, Bounds: &api_v1.Bounds{}}},
+		{name: "untyped initial value of an int64", req: &api_v1.CreateCounterRequest{Type: api_v1.CounterType_COUNTER_TYPE_INT64, InitialValue: 1}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+
+			_, err := service.CreateCounter(context.Background(), tt.req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
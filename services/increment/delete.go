@@ -0,0 +1,32 @@
+package increment
+
+import (
+	"context"
+	"log/slog"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+)
+
+// DeleteCounter deletes the named counter and records the caller in the log and the counter history
+// - ctx: context.Context context
+// - req: *api_v1.DeleteCounterRequest request
+// Returns *api_v1.DeleteCounterResponse with the deleted counter, NotFound if it does not
+// exist, or Aborted if the expected version does not match
+func (s *ServiceImpl) DeleteCounter(ctx context.Context, req *api_v1.DeleteCounterRequest) (*api_v1.DeleteCounterResponse, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	caller := callerIdentity(ctx)
+	number, err := s.repo.DeleteIf(name, attributed(ctx, func(number *interfaces.Number) error {
+		return checkPreconditions(number, nil, req.ExpectedVersion)
+	}))
+	if err != nil {
+		slog.Warn("Error deleting counter", "bucket", name, "caller", caller, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	slog.Info("Deleted counter", "bucket", name, "caller", caller, "number", number.Number, "version", number.Version)
+	return &api_v1.DeleteCounterResponse{Counter: toCounter(number)}, nil
+}
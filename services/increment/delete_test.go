@@ -0,0 +1,65 @@
+package increment
+
+import (
+	"context"
+	"testing"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDeleteCounter(t *testing.T) {
+
+	t.Run("deletes counter", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("DeleteIf", "retired").Return(&interfaces.Number{ID: "retired", Number: 8, Version: 3}, nil)
+
+		resp, err := service.DeleteCounter(context.Background(), &api_v1.DeleteCounterRequest{Name: "retired"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "retired", resp.Counter.Name)
+		assert.Equal(t, uint64(8), resp.Counter.Value)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("credits the caller with the delete", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		stored := &interfaces.Number{ID: "retired", Number: 8, Version: 3}
+		mockRepo.On("DeleteIf", "retired").Return(stored, nil)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(callerIDMetadataKey, "operator-jane", requestIDMetadataKey, "req-1"))
+
+		_, err := service.DeleteCounter(ctx, &api_v1.DeleteCounterRequest{Name: "retired"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, interfaces.ChangeSource{Caller: "operator-jane", RequestID: "req-1"}, stored.Source)
+	})
+
+	t.Run("unknown counter is not found", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("DeleteIf", "missing").Return(nil, interfaces.ErrNotFound)
+
+		resp, err := service.DeleteCounter(context.Background(), &api_v1.DeleteCounterRequest{Name: "missing"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("expected version mismatch is aborted", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("DeleteIf", "retired").Return(&interfaces.Number{ID: "retired", Number: 8, Version: 3}, nil)
+
+		resp, err := service.DeleteCounter(context.Background(), &api_v1.DeleteCounterRequest{Name: "retired", ExpectedVersion: proto.Uint64(2)})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+}
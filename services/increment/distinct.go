@@ -0,0 +1,186 @@
+package increment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxDistinctItems caps how many items a single AddDistinct request can add
+	maxDistinctItems = 10000
+	// maxDistinctSources caps how many counters a single Cardinality or MergeDistinct request reads
+	maxDistinctSources = 100
+)
+
+// checkDistinctSources validates the names of the distinct counters a request reads
+// - names: []string requested names
+// - field: string request field holding the names, used in error messages
+// Returns InvalidArgument if there are no names, too many names or a name is not valid
+func checkDistinctSources(names []string, field string) error {
+	if len(names) == 0 {
+		return status.Errorf(codes.InvalidArgument, "%s is required", field)
+	}
+	if len(names) > maxDistinctSources {
+		return status.Errorf(codes.InvalidArgument, "%s has %d names, at most %d are allowed", field, len(names), maxDistinctSources)
+	}
+	for _, name := range names {
+		if !rpc.ValidName(name) {
+			return status.Errorf(codes.InvalidArgument, "invalid counter name %q in %s", name, field)
+		}
+	}
+	return nil
+}
+
+// unionSketch reads the sketches of distinct counters and merges them
+// - txn: interfaces.INumberTxn transaction to read in
+// - names: []string names of the counters
+// Returns *sketch the merged sketch, ErrNotFound if a counter does not exist, or ErrTypeMismatch
+// if a counter is not a distinct counter
+func unionSketch(txn interfaces.INumberTxn, names []string) (*sketch, error) {
+	var union *sketch
+	for _, name := range names {
+		number, err := txn.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		if number.Version == 0 {
+			return nil, fmt.Errorf("%w: %s", interfaces.ErrNotFound, name)
+		}
+		sk, err := loadSketch(number)
+		if err != nil {
+			return nil, err
+		}
+		if union == nil {
+			union = sk
+		} else {
+			union = union.merge(sk)
+		}
+	}
+	return union, nil
+}
+
+// AddDistinct adds items to a distinct counter in a single transaction, creating it with the
+// default precision when it does not exist
+// - ctx: context.Context context
+// - req: *api_v1.AddDistinctRequest request
+// Returns *api_v1.AddDistinctResponse the estimate after the add, InvalidArgument if there are no
+// items or too many, or FailedPrecondition if the counter is not a distinct counter
+func (s *ServiceImpl) AddDistinct(ctx context.Context, req *api_v1.AddDistinctRequest) (*api_v1.AddDistinctResponse, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	items := req.GetItems()
+	if len(items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items are required")
+	}
+	if len(items) > maxDistinctItems {
+		return nil, status.Errorf(codes.InvalidArgument, "request has %d items, at most %d are allowed", len(items), maxDistinctItems)
+	}
+	expiry, err := fromExpiry(req.GetExpiry())
+	if err != nil {
+		return nil, err
+	}
+	number, err := s.repo.Update(name, attributed(ctx, func(number *interfaces.Number) error {
+		sk := newSketch(s.distinctPrecision)
+		if number.Version != 0 {
+			loaded, err := loadSketch(number)
+			if err != nil {
+				return err
+			}
+			sk = loaded
+		}
+		for _, item := range items {
+			sk.add(item)
+		}
+		setExpiry(number, expiry)
+		storeSketch(number, sk)
+		return nil
+	}))
+	if err != nil {
+		slog.Error("Error adding distinct items", "bucket", name, "items", len(items), "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	slog.Info("Added distinct items", "bucket", name, "items", len(items), "cardinality", number.Number)
+	return &api_v1.AddDistinctResponse{Cardinality: number.Number}, nil
+}
+
+// Cardinality estimates how many distinct items were added to one or more distinct counters,
+// the counters are read in a single transaction
+// - ctx: context.Context context
+// - req: *api_v1.CardinalityRequest request
+// Returns *api_v1.CardinalityResponse the estimate for the union of the counters, NotFound if a
+// counter does not exist, or FailedPrecondition if a counter is not a distinct counter
+func (s *ServiceImpl) Cardinality(ctx context.Context, req *api_v1.CardinalityRequest) (*api_v1.CardinalityResponse, error) {
+	names := req.GetNames()
+	if err := checkDistinctSources(names, "names"); err != nil {
+		return nil, err
+	}
+	var union *sketch
+	err := s.repo.Transact(func(txn interfaces.INumberTxn) error {
+		var err error
+		union, err = unionSketch(txn, names)
+		return err
+	})
+	if err != nil {
+		slog.Error("Error estimating cardinality", "names", names, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	return &api_v1.CardinalityResponse{Cardinality: union.estimate()}, nil
+}
+
+// MergeDistinct merges the sketches of distinct counters into another distinct counter in a
+// single transaction, creating it when it does not exist
+// - ctx: context.Context context
+// - req: *api_v1.MergeDistinctRequest request
+// Returns *api_v1.Counter the merged counter, NotFound if a source does not exist, or
+// FailedPrecondition if the counter or a source is not a distinct counter
+func (s *ServiceImpl) MergeDistinct(ctx context.Context, req *api_v1.MergeDistinctRequest) (*api_v1.Counter, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	sources := req.GetSources()
+	if err := checkDistinctSources(sources, "sources"); err != nil {
+		return nil, err
+	}
+	source := changeSource(ctx)
+	var merged *interfaces.Number
+	err = s.repo.Transact(func(txn interfaces.INumberTxn) error {
+		union, err := unionSketch(txn, sources)
+		if err != nil {
+			return err
+		}
+		number, err := txn.Get(name)
+		if err != nil {
+			return err
+		}
+		if number.Version != 0 {
+			sk, err := loadSketch(number)
+			if err != nil {
+				return err
+			}
+			union = union.merge(sk)
+		}
+		storeSketch(number, union)
+		number.Source = source
+		if err := txn.Put(number); err != nil {
+			return err
+		}
+		merged = number
+		return nil
+	})
+	if err != nil {
+		slog.Warn("Error merging distinct counters", "bucket", name, "sources", sources, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	slog.Info("Merged distinct counters", "bucket", name, "sources", sources, "cardinality", merged.Number)
+	return toCounter(merged), nil
+}
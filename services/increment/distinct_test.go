@@ -0,0 +1,261 @@
+package increment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// distinctNumber builds a stored distinct number holding the items item-from to item-to, to excluded
+func distinctNumber(name string, precision uint8, from int, to int) interfaces.Number {
+	number := interfaces.Number{ID: name, Version: 1}
+	storeSketch(&number, sketchOf(precision, from, to))
+	return number
+}
+
+func TestAddDistinct(t *testing.T) {
+	t.Run("creates a distinct counter with the configured precision", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default", WithDistinctPrecision(10))
+		number := &interfaces.Number{ID: "visitors"}
+		mockRepo.On("Update", "visitors").Return(number, nil)
+
+		resp, err := service.AddDistinct(context.Background(), &api_v1.AddDistinctRequest{Name: "visitors", Items: []string{"alice", "bob", "alice"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), resp.Cardinality)
+		assert.Equal(t, interfaces.NumberTypeDistinct, number.Type)
+		assert.Equal(t, uint8(10), number.Sketch[0])
+	})
+
+	t.Run("adds to an existing sketch", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		number := distinctNumber("visitors", 12, 0, 1000)
+		mockRepo.On("Update", "visitors").Return(&number, nil)
+
+		items := make([]string, 0, 1000)
+		for i := 500; i < 1500; i++ {
+			items = append(items, fmt.Sprintf("item-%d", i))
+		}
+		resp, err := service.AddDistinct(context.Background(), &api_v1.AddDistinctRequest{Name: "visitors", Items: items})
+
+		require.NoError(t, err)
+		assertEstimate(t, 1500, resp.Cardinality, 0.05)
+		assert.Equal(t, uint8(12), number.Sketch[0], "the precision of an existing sketch is kept")
+	})
+
+	t.Run("counter of another type is a failed precondition", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "orders").Return(&interfaces.Number{ID: "orders", Number: 3, Version: 1}, nil)
+
+		_, err := service.AddDistinct(context.Background(), &api_v1.AddDistinctRequest{Name: "orders", Items: []string{"alice"}})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("counter saved before versions were tracked is a failed precondition", func(t *testing.T) {
+		repo := legacyRepository(t, map[string]uint64{"orders": 500})
+		service := NewIncrementService(repo, "default")
+
+		_, err := service.AddDistinct(context.Background(), &api_v1.AddDistinctRequest{Name: "orders", Items: []string{"alice"}})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		stored, err := repo.FindByID("orders")
+		require.NoError(t, err)
+		assert.Equal(t, interfaces.NumberTypeUint64, stored.Type)
+		assert.Equal(t, uint64(500), stored.Number)
+	})
+
+	invalid := []struct {
+		name string
+		req  *api_v1.AddDistinctRequest
+	}{
+		{name: "no items", req: &api_v1.AddDistinctRequest{Name: "visitors"}},
+		{name: "too many items", req: &api_v1.AddDistinctRequest{Name: "visitors", Items: make([]string, maxDistinctItems+1)}},
+		{name: "invalid name", req: &api_v1.AddDistinctRequest{Name: "-visitors", Items: []string{"alice"}}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+
+			_, err := service.AddDistinct(context.Background(), tt.req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+		})
+	}
+}
+
+func TestCardinality(t *testing.T) {
+	numbers := func() map[string]interfaces.Number {
+		return map[string]interfaces.Number{
+			"visitors/mon": distinctNumber("visitors/mon", 14, 0, 3000),
+			"visitors/tue": distinctNumber("visitors/tue", 12, 2000, 5000),
+			"orders":       {ID: "orders", Number: 3, Version: 1},
+		}
+	}
+
+	t.Run("estimates a single counter", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Transact").Return(numbers(), nil)
+
+		resp, err := service.Cardinality(context.Background(), &api_v1.CardinalityRequest{Names: []string{"visitors/mon"}})
+
+		require.NoError(t, err)
+		assertEstimate(t, 3000, resp.Cardinality, 0.03)
+	})
+
+	t.Run("estimates the union of several counters", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Transact").Return(numbers(), nil)
+
+		resp, err := service.Cardinality(context.Background(), &api_v1.CardinalityRequest{Names: []string{"visitors/mon", "visitors/tue"}})
+
+		require.NoError(t, err)
+		assertEstimate(t, 5000, resp.Cardinality, 0.05)
+	})
+
+	t.Run("counter saved before versions were tracked is a failed precondition", func(t *testing.T) {
+		service := NewIncrementService(legacyRepository(t, map[string]uint64{"orders": 500}), "default")
+
+		_, err := service.Cardinality(context.Background(), &api_v1.CardinalityRequest{Names: []string{"orders"}})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	errs := []struct {
+		name  string
+		names []string
+		code  codes.Code
+	}{
+		{name: "missing counter", names: []string{"visitors/mon", "visitors/wed"}, code: codes.NotFound},
+		{name: "counter of another type", names: []string{"orders"}, code: codes.FailedPrecondition},
+		{name: "no names", code: codes.InvalidArgument},
+		{name: "empty name", names: []string{""}, code: codes.InvalidArgument},
+		{name: "too many names", names: strings.Split(strings.Repeat("visitors,", maxDistinctSources+1), ",")[:maxDistinctSources+1], code: codes.InvalidArgument},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+			mockRepo.On("Transact").Return(numbers(), nil)
+
+			_, err := service.Cardinality(context.Background(), &api_v1.CardinalityRequest{Names: tt.names})
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestMergeDistinct(t *testing.T) {
+	t.Run("creates the destination from the union of the sources", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		numbers := map[string]interfaces.Number{
+			"visitors/mon": distinctNumber("visitors/mon", 12, 0, 3000),
+			"visitors/tue": distinctNumber("visitors/tue", 12, 2000, 5000),
+		}
+		mockRepo.On("Transact").Return(numbers, nil)
+
+		counter, err := service.MergeDistinct(context.Background(), &api_v1.MergeDistinctRequest{
+			Name:    "visitors/week",
+			Sources: []string{"visitors/mon", "visitors/tue"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, api_v1.CounterType_COUNTER_TYPE_DISTINCT, counter.Type)
+		assertEstimate(t, 5000, counter.TypedValue.GetUint64Value(), 0.05)
+		assert.Equal(t, sketchOf(12, 0, 5000).encode(), numbers["visitors/week"].Sketch)
+		assert.Equal(t, distinctNumber("visitors/mon", 12, 0, 3000), numbers["visitors/mon"], "sources are left unchanged")
+	})
+
+	t.Run("merges into an existing destination at the lowest precision", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		numbers := map[string]interfaces.Number{
+			"visitors/mon":  distinctNumber("visitors/mon", 10, 0, 3000),
+			"visitors/week": distinctNumber("visitors/week", 14, 2000, 5000),
+		}
+		mockRepo.On("Transact").Return(numbers, nil)
+
+		counter, err := service.MergeDistinct(context.Background(), &api_v1.MergeDistinctRequest{
+			Name:    "visitors/week",
+			Sources: []string{"visitors/mon"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), counter.Version)
+		assert.Equal(t, sketchOf(10, 0, 5000).encode(), numbers["visitors/week"].Sketch)
+	})
+
+	t.Run("destination of another type is a failed precondition", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		numbers := map[string]interfaces.Number{
+			"visitors/mon": distinctNumber("visitors/mon", 12, 0, 10),
+			"orders":       {ID: "orders", Number: 3, Version: 1},
+		}
+		mockRepo.On("Transact").Return(numbers, nil)
+
+		_, err := service.MergeDistinct(context.Background(), &api_v1.MergeDistinctRequest{Name: "orders", Sources: []string{"visitors/mon"}})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, uint64(3), numbers["orders"].Number)
+	})
+
+	t.Run("destination saved before versions were tracked is a failed precondition", func(t *testing.T) {
+		repo := legacyRepository(t, map[string]uint64{"orders": 500})
+		service := NewIncrementService(repo, "default")
+		_, err := service.AddDistinct(context.Background(), &api_v1.AddDistinctRequest{Name: "visitors", Items: []string{"alice"}})
+		require.NoError(t, err)
+
+		_, err = service.MergeDistinct(context.Background(), &api_v1.MergeDistinctRequest{Name: "orders", Sources: []string{"visitors"}})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		stored, err := repo.FindByID("orders")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(500), stored.Number)
+	})
+
+	t.Run("missing source is not found", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		numbers := map[string]interfaces.Number{}
+		mockRepo.On("Transact").Return(numbers, nil)
+
+		_, err := service.MergeDistinct(context.Background(), &api_v1.MergeDistinctRequest{Name: "visitors/week", Sources: []string{"visitors/mon"}})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Empty(t, numbers)
+	})
+
+	t.Run("no sources", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		_, err := service.MergeDistinct(context.Background(), &api_v1.MergeDistinctRequest{Name: "visitors/week"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockRepo.AssertNotCalled(t, "Transact")
+	})
+}
+
+func TestWithDistinctPrecision(t *testing.T) {
+	assert.Equal(t, uint8(defaultDistinctPrecision), NewIncrementService(nil, "default").distinctPrecision)
+	assert.Equal(t, uint8(minDistinctPrecision), NewIncrementService(nil, "default", WithDistinctPrecision(0)).distinctPrecision)
+	assert.Equal(t, uint8(maxDistinctPrecision), NewIncrementService(nil, "default", WithDistinctPrecision(40)).distinctPrecision)
+}
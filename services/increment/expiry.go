@@ -0,0 +1,73 @@
+package increment
+
+import (
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// minExpiryTTL is the smallest TTL accepted, badger tracks expiry in whole seconds
+const minExpiryTTL = time.Second
+
+// fromExpiry converts a requested expiry into its stored form
+// - expiry: *api_v1.Expiry requested expiry, nil when the request leaves it alone
+// Returns *interfaces.Expiry stored expiry, or InvalidArgument if it is not valid
+func fromExpiry(expiry *api_v1.Expiry) (*interfaces.Expiry, error) {
+	if expiry == nil {
+		return nil, nil
+	}
+	if expiry.Ttl == nil || expiry.GetTtl().CheckValid() != nil {
+		return nil, status.Error(codes.InvalidArgument, "expiry ttl is required")
+	}
+	ttl := expiry.GetTtl().AsDuration()
+	if ttl < minExpiryTTL {
+		return nil, status.Errorf(codes.InvalidArgument, "expiry ttl %s is below %s", ttl, minExpiryTTL)
+	}
+	switch expiry.GetMode() {
+	case api_v1.ExpiryMode_EXPIRY_MODE_UNSPECIFIED, api_v1.ExpiryMode_EXPIRY_MODE_FIXED:
+		return &interfaces.Expiry{TTL: ttl}, nil
+	case api_v1.ExpiryMode_EXPIRY_MODE_SLIDING:
+		return &interfaces.Expiry{TTL: ttl, Sliding: true}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown expiry mode %v", expiry.GetMode())
+	}
+}
+
+// toExpiry converts a stored expiry into its API representation
+// - expiry: *interfaces.Expiry stored expiry
+// Returns *api_v1.Expiry expiry message, nil for numbers that never expire
+func toExpiry(expiry *interfaces.Expiry) *api_v1.Expiry {
+	if expiry == nil {
+		return nil
+	}
+	mode := api_v1.ExpiryMode_EXPIRY_MODE_FIXED
+	if expiry.Sliding {
+		mode = api_v1.ExpiryMode_EXPIRY_MODE_SLIDING
+	}
+	return &api_v1.Expiry{Ttl: durationpb.New(expiry.TTL), Mode: mode}
+}
+
+// remainingTTL reports how long a number has left before it expires
+// - number: *interfaces.Number number to check
+// Returns *durationpb.Duration time left, nil for numbers that never expire
+func remainingTTL(number *interfaces.Number) *durationpb.Duration {
+	if number.ExpiresAt.IsZero() {
+		return nil
+	}
+	return durationpb.New(max(time.Until(number.ExpiresAt), 0))
+}
+
+// setExpiry replaces the expiry of a number and restarts its deadline
+// - number: *interfaces.Number number to change
+// - expiry: *interfaces.Expiry new expiry, nil leaves the number unchanged
+func setExpiry(number *interfaces.Number, expiry *interfaces.Expiry) {
+	if expiry == nil {
+		return
+	}
+	number.Expiry = expiry
+	number.ExpiresAt = time.Time{}
+}
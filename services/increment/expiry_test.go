@@ -0,0 +1,49 @@
+package increment
+
+import (
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestFromExpiry(t *testing.T) {
+	tests := []struct {
+		name     string
+		expiry   *api_v1.Expiry
+		expected *interfaces.Expiry
+		wantErr  bool
+	}{
+		{name: "Unset", expiry: nil, expected: nil},
+		{name: "DefaultsToFixed", expiry: &api_v1.Expiry{Ttl: durationpb.New(time.Hour)}, expected: &interfaces.Expiry{TTL: time.Hour}},
+		{name: "Fixed", expiry: &api_v1.Expiry{Ttl: durationpb.New(time.Minute), Mode: api_v1.ExpiryMode_EXPIRY_MODE_FIXED}, expected: &interfaces.Expiry{TTL: time.Minute}},
+		{name: "Sliding", expiry: &api_v1.Expiry{Ttl: durationpb.New(time.Minute), Mode: api_v1.ExpiryMode_EXPIRY_MODE_SLIDING}, expected: &interfaces.Expiry{TTL: time.Minute, Sliding: true}},
+		{name: "MissingTTL", expiry: &api_v1.Expiry{}, wantErr: true},
+		{name: "BelowOneSecond", expiry: &api_v1.Expiry{Ttl: durationpb.New(500 * time.Millisecond)}, wantErr: true},
+		{name: "Negative", expiry: &api_v1.Expiry{Ttl: durationpb.New(-time.Minute)}, wantErr: true},
+		{name: "UnknownMode", expiry: &api_v1.Expiry{Ttl: durationpb.New(time.Minute), Mode: 42}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiry, err := fromExpiry(tt.expiry)
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, expiry)
+			}
+		})
+	}
+}
+
+func TestRemainingTTL(t *testing.T) {
+	assert.Nil(t, remainingTTL(&interfaces.Number{}))
+	assert.Equal(t, time.Duration(0), remainingTTL(&interfaces.Number{ExpiresAt: time.Now().Add(-time.Minute)}).AsDuration())
+	assert.InDelta(t, time.Hour, remainingTTL(&interfaces.Number{ExpiresAt: time.Now().Add(time.Hour)}).AsDuration(), float64(time.Minute))
+}
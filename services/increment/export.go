@@ -0,0 +1,45 @@
+package increment
+
+import (
+	"log/slog"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Export streams every counter under a prefix in name order from a single consistent read
+// - req: *api_v1.ExportRequest request
+// - stream: grpc.ServerStreamingServer[api_v1.ExportResponse] stream the counters are sent on
+// Returns nil once every counter is sent, InvalidArgument for a bad prefix, or the error of a failed send
+func (s *ServiceImpl) Export(req *api_v1.ExportRequest, stream grpc.ServerStreamingServer[api_v1.ExportResponse]) error {
+	prefix := req.GetPrefix()
+	if prefix != "" && !rpc.ValidName(prefix) {
+		return status.Errorf(codes.InvalidArgument, "invalid prefix %q", prefix)
+	}
+	ctx := stream.Context()
+	sent := 0
+	var revision uint64
+	err := s.repo.ForEach(prefix, func(number interfaces.Number, readRevision uint64) error {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		revision = readRevision
+		sent++
+		counter := toCounter(&number)
+		if number.Type == interfaces.NumberTypeDistinct {
+			// the estimate alone can not be imported, the sketch travels with it
+			counter.Sketch = number.Sketch
+		}
+		return stream.Send(&api_v1.ExportResponse{Counter: counter, ReadRevision: readRevision})
+	})
+	if err != nil {
+		slog.Warn("Error exporting counters", "prefix", prefix, "sent", sent, "error", err)
+		return rpc.ToStatus(err)
+	}
+	slog.Info("Exported counters", "prefix", prefix, "caller", callerIdentity(ctx), "counters", sent, "revision", revision)
+	return nil
+}
@@ -0,0 +1,108 @@
+package increment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeExportStream records the counters sent on an export stream
+type fakeExportStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*api_v1.ExportResponse
+}
+
+func (f *fakeExportStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeExportStream) Send(resp *api_v1.ExportResponse) error {
+	f.responses = append(f.responses, resp)
+	return nil
+}
+
+func TestExport(t *testing.T) {
+	t.Run("streams every counter at one revision", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		numbers := []interfaces.Number{
+			{ID: "a", Number: 1, Version: 2, Labels: map[string]string{"team": "ops"}},
+			{ID: "b", Number: 7, Version: 1, Owner: "bob"},
+		}
+		mockRepo.On("ForEach", "").Return(numbers, uint64(9), nil)
+		stream := &fakeExportStream{ctx: context.Background()}
+
+		err := service.Export(&api_v1.ExportRequest{}, stream)
+
+		require.NoError(t, err)
+		require.Len(t, stream.responses, 2)
+		assert.Equal(t, "a", stream.responses[0].Counter.Name)
+		assert.Equal(t, uint64(2), stream.responses[0].Counter.Version)
+		assert.Equal(t, "ops", stream.responses[0].Counter.Labels["team"])
+		assert.Equal(t, "bob", stream.responses[1].Counter.Owner)
+		for _, resp := range stream.responses {
+			assert.Equal(t, uint64(9), resp.ReadRevision)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("distinct counters carry their sketch", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		numbers := []interfaces.Number{
+			{ID: "a", Number: 1, Version: 2},
+			distinctNumber("visitors", 8, 0, 10),
+		}
+		mockRepo.On("ForEach", "").Return(numbers, uint64(9), nil)
+		stream := &fakeExportStream{ctx: context.Background()}
+
+		err := service.Export(&api_v1.ExportRequest{}, stream)
+
+		require.NoError(t, err)
+		require.Len(t, stream.responses, 2)
+		assert.Empty(t, stream.responses[0].Counter.Sketch)
+		assert.Equal(t, numbers[1].Sketch, stream.responses[1].Counter.Sketch)
+	})
+
+	t.Run("invalid prefix", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		err := service.Export(&api_v1.ExportRequest{Prefix: "bad name"}, &fakeExportStream{ctx: context.Background()})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("cancelled stream stops the export", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ForEach", "a").Return([]interfaces.Number{{ID: "a"}}, uint64(1), nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		stream := &fakeExportStream{ctx: ctx}
+
+		err := service.Export(&api_v1.ExportRequest{Prefix: "a"}, stream)
+
+		assert.Equal(t, codes.Canceled, status.Code(err))
+		assert.Empty(t, stream.responses)
+	})
+
+	t.Run("repository error is internal", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ForEach", "").Return(nil, uint64(0), errors.New("disk full"))
+
+		err := service.Export(&api_v1.ExportRequest{}, &fakeExportStream{ctx: context.Background()})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
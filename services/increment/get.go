@@ -0,0 +1,31 @@
+package increment
+
+import (
+	"context"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+)
+
+// Get reads the named counter without modifying it, as of a read revision when one is given
+// - ctx: context.Context context
+// - req: *api_v1.GetRequest request
+// Returns *api_v1.Counter counter, NotFound if it does not exist, or OutOfRange if the
+// read revision is no longer retained
+func (s *ServiceImpl) Get(ctx context.Context, req *api_v1.GetRequest) (*api_v1.Counter, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	var number *interfaces.Number
+	if req.GetReadRevision() != 0 {
+		number, err = s.repo.FindByIDAt(name, req.GetReadRevision())
+	} else {
+		number, err = s.repo.FindByID(name)
+	}
+	if err != nil {
+		return nil, rpc.ToStatus(err)
+	}
+	return toCounter(number), nil
+}
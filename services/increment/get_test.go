@@ -0,0 +1,90 @@
+package increment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGet(t *testing.T) {
+
+	t.Run("returns counter", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		mockRepo.On("FindByID", "visits").Return(&interfaces.Number{ID: "visits", Number: 9, Version: 3, UpdatedAt: updatedAt}, nil)
+
+		counter, err := service.Get(context.Background(), &api_v1.GetRequest{Name: "visits"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "visits", counter.Name)
+		assert.Equal(t, uint64(9), counter.Value)
+		assert.Equal(t, uint64(3), counter.Version)
+		assert.Equal(t, updatedAt, counter.UpdateTime.AsTime())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("reports remaining ttl", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		expiry := &interfaces.Expiry{TTL: time.Hour, Sliding: true}
+		mockRepo.On("FindByID", "session").Return(&interfaces.Number{ID: "session", Number: 1, Version: 1, Expiry: expiry, ExpiresAt: time.Now().Add(30 * time.Minute)}, nil)
+
+		counter, err := service.Get(context.Background(), &api_v1.GetRequest{Name: "session"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, api_v1.ExpiryMode_EXPIRY_MODE_SLIDING, counter.Expiry.GetMode())
+		assert.Equal(t, time.Hour, counter.Expiry.GetTtl().AsDuration())
+		assert.InDelta(t, 30*time.Minute, counter.RemainingTtl.AsDuration(), float64(time.Minute))
+	})
+
+	t.Run("reads at a revision", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("FindByIDAt", "visits", uint64(12)).Return(&interfaces.Number{ID: "visits", Number: 4, Version: 2}, nil)
+
+		counter, err := service.Get(context.Background(), &api_v1.GetRequest{Name: "visits", ReadRevision: 12})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(4), counter.Value)
+		mockRepo.AssertNotCalled(t, "FindByID", "visits")
+	})
+
+	t.Run("discarded revision is out of range", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("FindByIDAt", "visits", uint64(3)).Return(nil, interfaces.ErrOutOfRange)
+
+		_, err := service.Get(context.Background(), &api_v1.GetRequest{Name: "visits", ReadRevision: 3})
+
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("missing counter is not found", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("FindByID", "default").Return((*interfaces.Number)(nil), interfaces.ErrNotFound)
+
+		counter, err := service.Get(context.Background(), &api_v1.GetRequest{})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		mockRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		counter, err := service.Get(context.Background(), &api_v1.GetRequest{Name: "a b"})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,164 @@
+package increment
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// historyToken is the decoded form of the opaque history page token, it resumes
+// after the last returned entry and is bound to the request it was issued for
+type historyToken struct {
+	// Name is the counter the token was issued for
+	Name string `json:"n"`
+	// Start and End are the range the token was issued for, in unix nanoseconds
+	Start int64 `json:"s,omitempty"`
+	End   int64 `json:"e,omitempty"`
+	// At and Version identify the last entry returned on the previous page
+	At      int64  `json:"a"`
+	Version uint64 `json:"v"`
+}
+
+// historyRange resolves the optional time range of a history request
+// - r: *api_v1.TimeRange requested range, unset bounds are open
+// Returns the start and end of the range, zero for open bounds, or InvalidArgument
+func historyRange(r *api_v1.TimeRange) (time.Time, time.Time, error) {
+	var start, end time.Time
+	if r.GetStart() != nil {
+		if err := r.GetStart().CheckValid(); err != nil {
+			return start, end, status.Error(codes.InvalidArgument, "range start is not a valid timestamp")
+		}
+		start = r.GetStart().AsTime()
+	}
+	if r.GetEnd() != nil {
+		if err := r.GetEnd().CheckValid(); err != nil {
+			return start, end, status.Error(codes.InvalidArgument, "range end is not a valid timestamp")
+		}
+		end = r.GetEnd().AsTime()
+		if !start.Before(end) {
+			return start, end, status.Error(codes.InvalidArgument, "range start must be before its end")
+		}
+	}
+	return start, end, nil
+}
+
+// unixNano converts a time to unix nanoseconds, keeping zero times at zero
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// encodeHistoryToken encodes a history page token as an opaque string
+// - token: historyToken token to encode
+// Returns the url safe base64 encoded token
+func encodeHistoryToken(token historyToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeHistoryToken decodes and validates an opaque history page token
+// - raw: string token from the request, empty starts at the first page
+// - name: string counter name of the request
+// - start: time.Time range start of the request
+// - end: time.Time range end of the request
+// Returns the last entry of the previous page, nil for the first page, or InvalidArgument
+// if the token is malformed or was issued for another request
+func decodeHistoryToken(raw string, name string, start time.Time, end time.Time) (*interfaces.HistoryEntry, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "malformed page token")
+	}
+	var token historyToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "malformed page token")
+	}
+	if token.Name != name || token.Start != unixNano(start) || token.End != unixNano(end) {
+		return nil, status.Error(codes.InvalidArgument, "page token was issued for a different request")
+	}
+	return &interfaces.HistoryEntry{At: time.Unix(0, token.At), Version: token.Version}, nil
+}
+
+// toHistoryEntry converts a stored history entry into its API representation
+// - entry: *interfaces.HistoryEntry stored entry
+// Returns *api_v1.HistoryEntry entry message
+func toHistoryEntry(entry *interfaces.HistoryEntry) *api_v1.HistoryEntry {
+	result := &api_v1.HistoryEntry{
+		Version:            entry.Version,
+		Delta:              entry.Delta,
+		Time:               timestamppb.New(entry.At),
+		Caller:             entry.Caller,
+		RequestId:          entry.RequestID,
+		TypedPreviousValue: toTypedValue(&interfaces.Number{Number: entry.Previous, Type: entry.Type, Big: entry.BigPrevious}),
+		TypedValue:         toTypedValue(&interfaces.Number{Number: entry.Value, Type: entry.Type, Big: entry.BigValue}),
+		Deleted:            entry.Deleted,
+	}
+	if entry.Type == interfaces.NumberTypeUint64 {
+		result.PreviousValue = entry.Previous
+		result.Value = entry.Value
+	}
+	return result
+}
+
+// GetHistory lists the recorded writes of a counter oldest first one page at a time,
+// the history of a deleted counter is kept until the history retention drops it
+// - ctx: context.Context context
+// - req: *api_v1.GetHistoryRequest request
+// Returns *api_v1.GetHistoryResponse page of entries, or InvalidArgument for a bad range, page size or token
+func (s *ServiceImpl) GetHistory(ctx context.Context, req *api_v1.GetHistoryRequest) (*api_v1.GetHistoryResponse, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := historyRange(req.GetRange())
+	if err != nil {
+		return nil, err
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page size must not be negative")
+	}
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+	after, err := decodeHistoryToken(req.GetPageToken(), name, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// read one extra entry to learn whether another page follows
+	entries, err := s.repo.History(name, start, end, after, pageSize+1)
+	if err != nil {
+		slog.Error("Error reading history", "bucket", name, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	resp := &api_v1.GetHistoryResponse{}
+	for i := range entries[:min(len(entries), pageSize)] {
+		resp.Entries = append(resp.Entries, toHistoryEntry(&entries[i]))
+	}
+	if len(entries) > pageSize {
+		last := entries[pageSize-1]
+		resp.NextPageToken = encodeHistoryToken(historyToken{
+			Name:    name,
+			Start:   unixNano(start),
+			End:     unixNano(end),
+			At:      last.At.UnixNano(),
+			Version: last.Version,
+		})
+	}
+	return resp, nil
+}
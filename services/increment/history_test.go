@@ -0,0 +1,154 @@
+package increment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetHistory(t *testing.T) {
+	at := time.Unix(1700000000, 0).UTC()
+	entries := []interfaces.HistoryEntry{
+		{ID: "orders", Version: 1, Value: 5, Delta: 5, At: at, Caller: "alice", RequestID: "req-1"},
+		{ID: "orders", Version: 2, Previous: 5, Value: 3, Delta: -2, At: at.Add(time.Second), Caller: "bob"},
+		{ID: "orders", Version: 3, Previous: 3, Value: 4, Delta: 1, At: at.Add(2 * time.Second), Caller: "bob"},
+	}
+
+	t.Run("returns entries oldest first", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("History", "orders", time.Time{}, time.Time{}, (*interfaces.HistoryEntry)(nil), defaultPageSize+1).Return(entries, nil)
+
+		resp, err := service.GetHistory(context.Background(), &api_v1.GetHistoryRequest{Name: "orders"})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 3)
+		assert.Equal(t, uint64(1), resp.Entries[0].Version)
+		assert.Equal(t, uint64(5), resp.Entries[0].Value)
+		assert.Equal(t, "alice", resp.Entries[0].Caller)
+		assert.Equal(t, "req-1", resp.Entries[0].RequestId)
+		assert.Equal(t, at, resp.Entries[0].Time.AsTime())
+		assert.Equal(t, uint64(5), resp.Entries[1].PreviousValue)
+		assert.Equal(t, int64(-2), resp.Entries[1].Delta)
+		assert.Empty(t, resp.NextPageToken)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("typed entries carry typed values", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("History", "balance", time.Time{}, time.Time{}, (*interfaces.HistoryEntry)(nil), defaultPageSize+1).Return([]interfaces.HistoryEntry{
+			{ID: "balance", Version: 2, Previous: 3, Value: uint64(1<<64 - 2), Type: interfaces.NumberTypeInt64, Delta: -5, At: at},
+		}, nil)
+
+		resp, err := service.GetHistory(context.Background(), &api_v1.GetHistoryRequest{Name: "balance"})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 1)
+		assert.Equal(t, int64(3), resp.Entries[0].TypedPreviousValue.GetInt64Value())
+		assert.Equal(t, int64(-2), resp.Entries[0].TypedValue.GetInt64Value())
+		assert.Equal(t, int64(-5), resp.Entries[0].Delta)
+		assert.Zero(t, resp.Entries[0].Value)
+	})
+
+	t.Run("deletes are flagged", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("History", "retired", time.Time{}, time.Time{}, (*interfaces.HistoryEntry)(nil), defaultPageSize+1).Return([]interfaces.HistoryEntry{
+			{ID: "retired", Version: 4, Previous: 8, Delta: -8, Deleted: true, At: at, Caller: "alice"},
+		}, nil)
+
+		resp, err := service.GetHistory(context.Background(), &api_v1.GetHistoryRequest{Name: "retired"})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 1)
+		assert.True(t, resp.Entries[0].Deleted)
+		assert.Equal(t, uint64(8), resp.Entries[0].PreviousValue)
+		assert.Zero(t, resp.Entries[0].Value)
+	})
+
+	t.Run("pages through entries", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		start := at.Add(-time.Hour)
+		rng := &api_v1.TimeRange{Start: timestamppb.New(start)}
+		mockRepo.On("History", "orders", start, time.Time{}, (*interfaces.HistoryEntry)(nil), 3).Return(entries, nil)
+
+		first, err := service.GetHistory(context.Background(), &api_v1.GetHistoryRequest{Name: "orders", Range: rng, PageSize: 2})
+
+		require.NoError(t, err)
+		assert.Len(t, first.Entries, 2)
+		require.NotEmpty(t, first.NextPageToken)
+
+		mockRepo.On("History", "orders", start, time.Time{}, mock.MatchedBy(func(after *interfaces.HistoryEntry) bool {
+			return after != nil && after.Version == 2 && after.At.Equal(entries[1].At)
+		}), 3).Return(entries[2:], nil)
+
+		second, err := service.GetHistory(context.Background(), &api_v1.GetHistoryRequest{Name: "orders", Range: rng, PageSize: 2, PageToken: first.NextPageToken})
+
+		require.NoError(t, err)
+		require.Len(t, second.Entries, 1)
+		assert.Equal(t, uint64(3), second.Entries[0].Version)
+		assert.Empty(t, second.NextPageToken)
+	})
+
+	t.Run("token from another request is invalid", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		token := encodeHistoryToken(historyToken{Name: "orders", At: at.UnixNano(), Version: 1})
+
+		_, err := service.GetHistory(context.Background(), &api_v1.GetHistoryRequest{Name: "other", PageToken: token})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("malformed token is invalid", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		_, err := service.GetHistory(context.Background(), &api_v1.GetHistoryRequest{Name: "orders", PageToken: "!!"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("inverted range is invalid", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		_, err := service.GetHistory(context.Background(), &api_v1.GetHistoryRequest{
+			Name:  "orders",
+			Range: &api_v1.TimeRange{Start: timestamppb.New(at), End: timestamppb.New(at.Add(-time.Second))},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("negative page size is invalid", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		_, err := service.GetHistory(context.Background(), &api_v1.GetHistoryRequest{Name: "orders", PageSize: -1})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("repository error is internal", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("History", "orders", time.Time{}, time.Time{}, (*interfaces.HistoryEntry)(nil), defaultPageSize+1).Return(nil, errors.New("disk full"))
+
+		_, err := service.GetHistory(context.Background(), &api_v1.GetHistoryRequest{Name: "orders"})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -0,0 +1,96 @@
+package increment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// idempotencyMetadataKey is the metadata key an idempotency key can be sent in
+	idempotencyMetadataKey = "idempotency-key"
+	// maxIdempotencyKeyLength caps the size of idempotency keys
+	maxIdempotencyKeyLength = 128
+)
+
+// idempotencyKey picks the idempotency key of a request
+// - ctx: context.Context request context carrying metadata
+// - field: string key from the request message, it takes precedence over metadata
+// Returns the key, empty when the request has none, or InvalidArgument if it is too long
+func idempotencyKey(ctx context.Context, field string) (string, error) {
+	key := field
+	if key == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(idempotencyMetadataKey); len(values) > 0 {
+				key = values[0]
+			}
+		}
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return "", status.Errorf(codes.InvalidArgument, "idempotency key is longer than %d bytes", maxIdempotencyKeyLength)
+	}
+	return key, nil
+}
+
+// requestFingerprint identifies the payload of a mutation for its idempotency record
+// - change: string describes the change the request makes to the value
+// - expiry: *interfaces.Expiry expiry the request sets, nil when it leaves it unchanged
+// Returns the fingerprint, requests without an expiry keep the bare change so records
+// stored before expiries were fingerprinted still match
+func requestFingerprint(change string, expiry *interfaces.Expiry) string {
+	if expiry == nil {
+		return change
+	}
+	mode := "fixed"
+	if expiry.Sliding {
+		mode = "sliding"
+	}
+	return fmt.Sprintf("%s;expiry:%s:%d", change, mode, expiry.TTL)
+}
+
+// mutate applies fn to a counter, storing the result under the idempotency key in the
+// same transaction so a retried request replays the first result instead of applying fn again
+// - name: string counter name
+// - key: string idempotency key, empty applies fn without recording the result
+// - fingerprint: string identifies the request payload, reusing a key with another payload fails
+// - fn: func(*interfaces.Number) error mutation to apply
+// Returns the counter, only its value and type are set when a response is replayed, or
+// FailedPrecondition if the key was used with another payload
+func (s *ServiceImpl) mutate(name string, key string, fingerprint string, fn func(number *interfaces.Number) error) (*interfaces.Number, error) {
+	if key == "" {
+		return s.repo.Update(name, fn)
+	}
+	var result *interfaces.Number
+	err := s.repo.Transact(func(txn interfaces.INumberTxn) error {
+		record, err := txn.GetIdempotency(name, key)
+		if err != nil {
+			return err
+		}
+		if record != nil {
+			if record.Fingerprint != fingerprint {
+				return fmt.Errorf("%w: idempotency key %q was used with a different request", interfaces.ErrPreconditionFailed, key)
+			}
+			slog.Info("Replaying idempotent response", "bucket", name, "key", key)
+			result = &interfaces.Number{ID: name, Number: record.Value, Type: record.Type, Big: record.Big}
+			return nil
+		}
+		number, err := txn.Get(name)
+		if err != nil {
+			return err
+		}
+		if err := fn(number); err != nil {
+			return err
+		}
+		if err := txn.Put(number); err != nil {
+			return err
+		}
+		result = number
+		return txn.PutIdempotency(name, key, interfaces.IdempotencyRecord{Fingerprint: fingerprint, Value: number.Number, Type: number.Type, Big: number.Big}, s.idempotencyTTL)
+	})
+	return result, err
+}
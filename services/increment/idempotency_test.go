@@ -0,0 +1,111 @@
+package increment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/repositories/number"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	withMetadata := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyMetadataKey, "from-metadata"))
+
+	key, err := idempotencyKey(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Empty(t, key)
+
+	key, err = idempotencyKey(withMetadata, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "from-metadata", key)
+
+	key, err = idempotencyKey(withMetadata, "from-field")
+	assert.NoError(t, err)
+	assert.Equal(t, "from-field", key)
+
+	_, err = idempotencyKey(context.Background(), string(make([]byte, maxIdempotencyKeyLength+1)))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestIdempotentRetries(t *testing.T) {
+	// Open a Badger database so records are stored next to the counters
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	defer db.Close()
+	service := NewIncrementService(number.NewBadgerNumberRepository(db), "default")
+	ctx := context.Background()
+
+	t.Run("increment replays the first response", func(t *testing.T) {
+		first, err := service.Increment(ctx, &api_v1.IncrementRequest{Name: "retried", IdempotencyKey: "req-1"})
+		require.NoError(t, err)
+		second, err := service.Increment(ctx, &api_v1.IncrementRequest{Name: "retried", IdempotencyKey: "req-1"})
+		require.NoError(t, err)
+		third, err := service.Increment(ctx, &api_v1.IncrementRequest{Name: "retried", IdempotencyKey: "req-2"})
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(1), first.Value)
+		assert.Equal(t, uint64(1), second.Value)
+		assert.Equal(t, uint64(2), third.Value)
+	})
+
+	t.Run("add replays keys from metadata", func(t *testing.T) {
+		withKey := metadata.NewIncomingContext(ctx, metadata.Pairs(idempotencyMetadataKey, "batch-7"))
+		first, err := service.Add(withKey, &api_v1.AddRequest{Name: "billing", Delta: 40})
+		require.NoError(t, err)
+		second, err := service.Add(withKey, &api_v1.AddRequest{Name: "billing", Delta: 40})
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(40), first.Value)
+		assert.Equal(t, uint64(40), second.Value)
+	})
+
+	t.Run("reusing a key with another payload fails", func(t *testing.T) {
+		_, err := service.Add(ctx, &api_v1.AddRequest{Name: "billing", Delta: 1, IdempotencyKey: "batch-8"})
+		require.NoError(t, err)
+
+		_, err = service.Add(ctx, &api_v1.AddRequest{Name: "billing", Delta: 2, IdempotencyKey: "batch-8"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		_, err = service.Increment(ctx, &api_v1.IncrementRequest{Name: "billing", IdempotencyKey: "batch-8"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("reusing a key with another expiry fails", func(t *testing.T) {
+		minute := &api_v1.Expiry{Ttl: durationpb.New(time.Minute)}
+		hour := &api_v1.Expiry{Ttl: durationpb.New(time.Hour)}
+		sliding := &api_v1.Expiry{Ttl: durationpb.New(time.Minute), Mode: api_v1.ExpiryMode_EXPIRY_MODE_SLIDING}
+
+		_, err := service.Increment(ctx, &api_v1.IncrementRequest{Name: "expiring", IdempotencyKey: "exp-1", Expiry: minute})
+		require.NoError(t, err)
+		_, err = service.Increment(ctx, &api_v1.IncrementRequest{Name: "expiring", IdempotencyKey: "exp-1", Expiry: minute})
+		require.NoError(t, err)
+		for _, expiry := range []*api_v1.Expiry{nil, hour, sliding} {
+			_, err = service.Increment(ctx, &api_v1.IncrementRequest{Name: "expiring", IdempotencyKey: "exp-1", Expiry: expiry})
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err), expiry.String())
+		}
+
+		_, err = service.Add(ctx, &api_v1.AddRequest{Name: "expiring", Delta: 3, IdempotencyKey: "exp-2"})
+		require.NoError(t, err)
+		_, err = service.Add(ctx, &api_v1.AddRequest{Name: "expiring", Delta: 3, IdempotencyKey: "exp-2", Expiry: hour})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("failed requests do not record the key", func(t *testing.T) {
+		_, err := service.Add(ctx, &api_v1.AddRequest{Name: "small", Delta: -1, IdempotencyKey: "undo-1"})
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+
+		_, err = service.Set(ctx, &api_v1.SetRequest{Name: "small", Value: 5})
+		require.NoError(t, err)
+		resp, err := service.Add(ctx, &api_v1.AddRequest{Name: "small", Delta: -1, IdempotencyKey: "undo-1"})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), resp.Value)
+	})
+}
@@ -0,0 +1,359 @@
+package increment
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxImportCounters caps how many counters a single import can hold, they are
+	// buffered so the whole stream is validated before anything is written
+	maxImportCounters = 10000
+	// importChunkSize is how many counters are written per transaction
+	importChunkSize = 500
+	// importErrorReason is the ErrorInfo reason attached when an imported counter is invalid
+	importErrorReason = "INVALID_COUNTER"
+	// importIncompleteReason is the ErrorInfo reason attached when writing a chunk fails
+	importIncompleteReason = "IMPORT_INCOMPLETE"
+)
+
+// importedCounter is a validated counter waiting to be written
+type importedCounter struct {
+	name      string
+	version   uint64
+	value     interfaces.Number
+	bounds    *interfaces.Bounds
+	expiry    *interfaces.Expiry
+	remaining time.Duration
+	windows   []interfaces.Window
+	metadata  counterMetadata
+}
+
+// invalidCounter builds the error returned for a counter that failed validation
+// - index: position of the counter in the stream, counting from 0 after the options
+// - name: name of the counter
+// - err: the validation failure
+// Returns an InvalidArgument status carrying the index and name in an ErrorInfo detail
+func invalidCounter(index int, name string, err error) error {
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("counter %d (%s): %s", index, name, status.Convert(err).Message()))
+	detailed, derr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: importErrorReason,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"index": strconv.Itoa(index),
+			"name":  name,
+		},
+	})
+	if derr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// incompleteImport builds the error returned when writing a chunk of counters fails, an
+// import is not atomic and the chunks before it stay written
+// - index: position of the first counter of the failing chunk, every counter before it was written
+// - resp: *api_v1.ImportResponse results of the counters written so far
+// - err: the write failure
+// Returns a status with the code of err carrying the index in an ErrorInfo detail and the
+// results of the written counters in an ImportResponse detail
+func incompleteImport(index int, resp *api_v1.ImportResponse, err error) error {
+	cause := status.Convert(rpc.ToStatus(err))
+	st := status.New(cause.Code(), fmt.Sprintf("import stopped at counter %d, the counters before it were written: %s", index, cause.Message()))
+	detailed, derr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: importIncompleteReason,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"index": strconv.Itoa(index),
+		},
+	}, resp)
+	if derr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// fromImportedCounter validates a counter read from an import stream
+// - counter: *api_v1.Counter counter as exported
+// Returns the counter in its stored form, or InvalidArgument if it is not valid
+func fromImportedCounter(counter *api_v1.Counter) (importedCounter, error) {
+	if !rpc.ValidName(counter.GetName()) {
+		return importedCounter{}, status.Errorf(codes.InvalidArgument, "invalid counter name %q", counter.GetName())
+	}
+	numberType, err := fromCounterType(counter.GetType())
+	if err != nil {
+		return importedCounter{}, err
+	}
+	value := interfaces.Number{Number: counter.GetValue()}
+	switch {
+	case numberType == interfaces.NumberTypeDistinct:
+		// the value of a distinct counter is the estimate of its sketch
+		sk, err := decodeSketch(counter.GetSketch())
+		if err != nil {
+			return importedCounter{}, status.Errorf(codes.InvalidArgument, "invalid sketch: %v", err)
+		}
+		value = interfaces.Number{}
+		storeSketch(&value, sk)
+	case len(counter.GetSketch()) > 0:
+		return importedCounter{}, status.Error(codes.InvalidArgument, "only distinct counters carry a sketch")
+	case counter.TypedValue != nil:
+		value, err = fromTypedValue(counter.GetTypedValue())
+		if err != nil {
+			return importedCounter{}, err
+		}
+		if value.Type != numberType {
+			return importedCounter{}, status.Errorf(codes.InvalidArgument, "typed value of a %s counter is not a %s value", typeNames[numberType], typeNames[numberType])
+		}
+	case numberType != interfaces.NumberTypeUint64:
+		return importedCounter{}, status.Errorf(codes.InvalidArgument, "%s counters need a typed value", typeNames[numberType])
+	}
+	bounds, err := fromBounds(counter.GetBounds())
+	if err != nil {
+		return importedCounter{}, err
+	}
+	if numberType != interfaces.NumberTypeUint64 && (bounds != nil || len(counter.GetWindows()) > 0) {
+		return importedCounter{}, status.Error(codes.InvalidArgument, "bounds and windows are only supported by uint64 counters")
+	}
+	if bounds != nil && (value.Number < bounds.Min || value.Number > bounds.Max) {
+		return importedCounter{}, status.Errorf(codes.InvalidArgument, "value %d is outside [%d, %d]", value.Number, bounds.Min, bounds.Max)
+	}
+	expiry, err := fromExpiry(counter.GetExpiry())
+	if err != nil {
+		return importedCounter{}, err
+	}
+	var remaining time.Duration
+	if expiry != nil && !expiry.Sliding && counter.RemainingTtl != nil {
+		if counter.GetRemainingTtl().CheckValid() != nil {
+			return importedCounter{}, status.Error(codes.InvalidArgument, "invalid remaining ttl")
+		}
+		remaining = counter.GetRemainingTtl().AsDuration()
+	}
+	windows, err := fromWindows(counter.GetWindows())
+	if err != nil {
+		return importedCounter{}, err
+	}
+	md, err := fromMetadata(&api_v1.CounterMetadata{
+		Labels:      counter.GetLabels(),
+		Description: counter.GetDescription(),
+		Owner:       counter.GetOwner(),
+	})
+	if err != nil {
+		return importedCounter{}, err
+	}
+	return importedCounter{
+		name:      counter.GetName(),
+		version:   counter.GetVersion(),
+		value:     value,
+		bounds:    bounds,
+		expiry:    expiry,
+		remaining: remaining,
+		windows:   windows,
+		metadata:  md,
+	}, nil
+}
+
+// apply replaces the settings and value of a number with the imported ones, the exported
+// version is restored unless the stored number is already past it
+// - number: *interfaces.Number number to change
+// - now: time the import is written at
+func (c importedCounter) apply(number *interfaces.Number, now time.Time) {
+	number.Version = c.version
+	number.Type = c.value.Type
+	number.Number = c.value.Number
+	number.Big = c.value.Big
+	number.Sketch = c.value.Sketch
+	number.Bounds = c.bounds
+	number.Expiry = nil
+	number.ExpiresAt = time.Time{}
+	setExpiry(number, c.expiry)
+	if c.remaining > 0 {
+		// a fixed expiry keeps the deadline it had when exported
+		number.ExpiresAt = now.Add(c.remaining)
+	}
+	number.Windows = c.windows
+	c.metadata.apply(number, nil)
+}
+
+// importAction decides what an import does to a stored number
+// - number: *interfaces.Number stored number, version 0 when it does not exist
+// - counter: importedCounter imported counter
+// - mode: api_v1.ImportConflictMode how existing numbers are treated
+// Returns the action to take, or ErrTypeMismatch when values of different types would be compared
+func importAction(number *interfaces.Number, counter importedCounter, mode api_v1.ImportConflictMode) (api_v1.ImportAction, error) {
+	switch {
+	case number.Version == 0:
+		return api_v1.ImportAction_IMPORT_ACTION_CREATED, nil
+	case mode == api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_OVERWRITE:
+		return api_v1.ImportAction_IMPORT_ACTION_OVERWRITTEN, nil
+	case mode == api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_MAX_WINS:
+		if err := checkType(number, counter.value.Type); err != nil {
+			return 0, err
+		}
+		if compareValues(&counter.value, number) > 0 {
+			return api_v1.ImportAction_IMPORT_ACTION_OVERWRITTEN, nil
+		}
+	}
+	return api_v1.ImportAction_IMPORT_ACTION_SKIPPED, nil
+}
+
+// toImportResult reports a stored number as left unchanged by an import
+// - number: *interfaces.Number stored number, version 0 when it does not exist
+// - action: api_v1.ImportAction action taken
+// Returns *api_v1.ImportResult result with the previous and current value set to the stored one
+func toImportResult(number *interfaces.Number, action api_v1.ImportAction) *api_v1.ImportResult {
+	result := &api_v1.ImportResult{
+		Name:               number.ID,
+		Action:             action,
+		TypedPreviousValue: toTypedValue(number),
+		TypedValue:         toTypedValue(number),
+	}
+	if number.Type == interfaces.NumberTypeUint64 {
+		result.PreviousValue = number.Number
+		result.Value = number.Number
+	}
+	return result
+}
+
+// readImport reads the options and every counter of an import stream
+// - stream: grpc.ClientStreamingServer[api_v1.ImportRequest, api_v1.ImportResponse] stream to read
+// Returns the options and the validated counters in stream order, or InvalidArgument if the
+// stream is malformed or any counter is invalid
+func readImport(stream grpc.ClientStreamingServer[api_v1.ImportRequest, api_v1.ImportResponse]) (*api_v1.ImportOptions, []importedCounter, error) {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, status.Error(codes.InvalidArgument, "import stream has no options")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	options := first.GetOptions()
+	if options == nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "the first message of an import stream must carry the options")
+	}
+	switch options.GetConflictMode() {
+	case api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_OVERWRITE,
+		api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP,
+		api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_MAX_WINS:
+	default:
+		return nil, nil, status.Errorf(codes.InvalidArgument, "unknown conflict mode %v", options.GetConflictMode())
+	}
+
+	counters := []importedCounter{}
+	seen := map[string]bool{}
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return options, counters, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		index := len(counters)
+		if index == maxImportCounters {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "import has more than %d counters", maxImportCounters)
+		}
+		counter := req.GetCounter()
+		if counter == nil {
+			return nil, nil, invalidCounter(index, "", status.Error(codes.InvalidArgument, "message carries no counter"))
+		}
+		imported, err := fromImportedCounter(counter)
+		if err != nil {
+			return nil, nil, invalidCounter(index, counter.GetName(), err)
+		}
+		if seen[imported.name] {
+			return nil, nil, invalidCounter(index, imported.name, status.Error(codes.InvalidArgument, "counter appears more than once"))
+		}
+		seen[imported.name] = true
+		counters = append(counters, imported)
+	}
+}
+
+// Import reads a stream of counters, validates all of them and then writes them
+// - stream: grpc.ClientStreamingServer[api_v1.ImportRequest, api_v1.ImportResponse] stream of options and counters
+// Returns nil once the response is sent, InvalidArgument without writing anything when the
+// stream is malformed or any counter is invalid, or, as counters are written in chunks of
+// importChunkSize and the import is not atomic, the error of a failed chunk carrying its
+// index and the results of the chunks written before it
+func (s *ServiceImpl) Import(stream grpc.ClientStreamingServer[api_v1.ImportRequest, api_v1.ImportResponse]) error {
+	ctx := stream.Context()
+	options, counters, err := readImport(stream)
+	if err != nil {
+		slog.Warn("Error reading import", "error", err)
+		return err
+	}
+
+	mode := options.GetConflictMode()
+	dryRun := options.GetDryRun()
+	source := changeSource(ctx)
+	resp := &api_v1.ImportResponse{Results: make([]*api_v1.ImportResult, 0, len(counters)), DryRun: dryRun}
+	for start := 0; start < len(counters); start += importChunkSize {
+		if err := ctx.Err(); err != nil {
+			return incompleteImport(start, resp, status.FromContextError(err).Err())
+		}
+		chunk := counters[start:min(start+importChunkSize, len(counters))]
+		var results []*api_v1.ImportResult
+		err := s.repo.Transact(func(txn interfaces.INumberTxn) error {
+			results = make([]*api_v1.ImportResult, 0, len(chunk))
+			now := time.Now()
+			for _, counter := range chunk {
+				number, err := txn.Get(counter.name)
+				if err != nil {
+					return err
+				}
+				action, err := importAction(number, counter, mode)
+				if err != nil {
+					return err
+				}
+				result := toImportResult(number, action)
+				results = append(results, result)
+				if action == api_v1.ImportAction_IMPORT_ACTION_SKIPPED {
+					continue
+				}
+				result.TypedValue = toTypedValue(&counter.value)
+				result.Value = 0
+				if counter.value.Type == interfaces.NumberTypeUint64 {
+					result.Value = counter.value.Number
+				}
+				if dryRun {
+					continue
+				}
+				counter.apply(number, now)
+				number.Source = source
+				if err := txn.Put(number); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			slog.Warn("Error importing counters", "written", len(resp.Results), "error", err)
+			return incompleteImport(start, resp, err)
+		}
+		for _, result := range results {
+			switch result.Action {
+			case api_v1.ImportAction_IMPORT_ACTION_CREATED:
+				resp.Created++
+			case api_v1.ImportAction_IMPORT_ACTION_OVERWRITTEN:
+				resp.Overwritten++
+			default:
+				resp.Skipped++
+			}
+		}
+		resp.Results = append(resp.Results, results...)
+	}
+	slog.Info("Imported counters", "caller", callerIdentity(ctx), "mode", mode, "dry_run", dryRun,
+		"created", resp.Created, "overwritten", resp.Overwritten, "skipped", resp.Skipped)
+	return stream.SendAndClose(resp)
+}
@@ -0,0 +1,382 @@
+package increment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// fakeImportStream replays queued requests and records the response of an import stream
+type fakeImportStream struct {
+	grpc.ServerStream
+	requests []*api_v1.ImportRequest
+	response *api_v1.ImportResponse
+}
+
+func (f *fakeImportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (f *fakeImportStream) Recv() (*api_v1.ImportRequest, error) {
+	if len(f.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := f.requests[0]
+	f.requests = f.requests[1:]
+	return req, nil
+}
+
+func (f *fakeImportStream) SendAndClose(resp *api_v1.ImportResponse) error {
+	f.response = resp
+	return nil
+}
+
+// importStream builds a stream of the options followed by the counters
+func importStream(mode api_v1.ImportConflictMode, dryRun bool, counters ...*api_v1.Counter) *fakeImportStream {
+	stream := &fakeImportStream{requests: []*api_v1.ImportRequest{
+		{Item: &api_v1.ImportRequest_Options{Options: &api_v1.ImportOptions{ConflictMode: mode, DryRun: dryRun}}},
+	}}
+	for _, counter := range counters {
+		stream.requests = append(stream.requests, &api_v1.ImportRequest{Item: &api_v1.ImportRequest_Counter{Counter: counter}})
+	}
+	return stream
+}
+
+func TestImport(t *testing.T) {
+	stored := func() map[string]interfaces.Number {
+		return map[string]interfaces.Number{
+			"low":  {ID: "low", Number: 3, Version: 4},
+			"high": {ID: "high", Number: 50, Version: 2},
+		}
+	}
+	counters := func() []*api_v1.Counter {
+		return []*api_v1.Counter{
+			{Name: "low", Value: 10},
+			{Name: "high", Value: 20},
+			{Name: "new", Value: 5, Labels: map[string]string{"team": "ops"}, Owner: "alice"},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		mode     api_v1.ImportConflictMode
+		actions  []api_v1.ImportAction
+		expected map[string]uint64
+	}{
+		{
+			name: "overwrite",
+			mode: api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_OVERWRITE,
+			actions: []api_v1.ImportAction{
+				api_v1.ImportAction_IMPORT_ACTION_OVERWRITTEN, api_v1.ImportAction_IMPORT_ACTION_OVERWRITTEN, api_v1.ImportAction_IMPORT_ACTION_CREATED,
+			},
+			expected: map[string]uint64{"low": 10, "high": 20, "new": 5},
+		},
+		{
+			name: "skip",
+			mode: api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP,
+			actions: []api_v1.ImportAction{
+				api_v1.ImportAction_IMPORT_ACTION_SKIPPED, api_v1.ImportAction_IMPORT_ACTION_SKIPPED, api_v1.ImportAction_IMPORT_ACTION_CREATED,
+			},
+			expected: map[string]uint64{"low": 3, "high": 50, "new": 5},
+		},
+		{
+			name: "max wins",
+			mode: api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_MAX_WINS,
+			actions: []api_v1.ImportAction{
+				api_v1.ImportAction_IMPORT_ACTION_OVERWRITTEN, api_v1.ImportAction_IMPORT_ACTION_SKIPPED, api_v1.ImportAction_IMPORT_ACTION_CREATED,
+			},
+			expected: map[string]uint64{"low": 10, "high": 50, "new": 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+			numbers := stored()
+			mockRepo.On("Transact").Return(numbers, nil)
+			stream := importStream(tt.mode, false, counters()...)
+
+			err := service.Import(stream)
+
+			require.NoError(t, err)
+			require.Len(t, stream.response.Results, 3)
+			for i, action := range tt.actions {
+				assert.Equal(t, action, stream.response.Results[i].Action, stream.response.Results[i].Name)
+				assert.Equal(t, tt.expected[stream.response.Results[i].Name], stream.response.Results[i].Value)
+			}
+			for name, value := range tt.expected {
+				assert.Equal(t, value, numbers[name].Number, name)
+			}
+			assert.Equal(t, "ops", numbers["new"].Labels["team"])
+			assert.Equal(t, "alice", numbers["new"].Owner)
+			assert.False(t, stream.response.DryRun)
+		})
+	}
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		numbers := stored()
+		mockRepo.On("Transact").Return(numbers, nil)
+		stream := importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_OVERWRITE, true, counters()...)
+
+		err := service.Import(stream)
+
+		require.NoError(t, err)
+		assert.True(t, stream.response.DryRun)
+		assert.Equal(t, uint32(1), stream.response.Created)
+		assert.Equal(t, uint32(2), stream.response.Overwritten)
+		assert.Equal(t, uint64(3), stream.response.Results[0].PreviousValue)
+		assert.Equal(t, uint64(10), stream.response.Results[0].Value)
+		assert.Equal(t, stored(), numbers)
+	})
+
+	t.Run("fixed expiry keeps the remaining ttl", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		numbers := map[string]interfaces.Number{}
+		mockRepo.On("Transact").Return(numbers, nil)
+		stream := importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_OVERWRITE, false, &api_v1.Counter{
+			Name:         "session",
+			Value:        1,
+			Expiry:       &api_v1.Expiry{Ttl: durationpb.New(time.Hour), Mode: api_v1.ExpiryMode_EXPIRY_MODE_FIXED},
+			RemainingTtl: durationpb.New(10 * time.Minute),
+		})
+
+		err := service.Import(stream)
+
+		require.NoError(t, err)
+		imported := numbers["session"]
+		require.NotNil(t, imported.Expiry)
+		assert.Equal(t, time.Hour, imported.Expiry.TTL)
+		assert.WithinDuration(t, time.Now().Add(10*time.Minute), imported.ExpiresAt, time.Minute)
+	})
+
+	t.Run("typed counters keep their type", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		numbers := map[string]interfaces.Number{
+			"balance": {ID: "balance", Number: uint64(1<<64 - 5), Version: 1, Type: interfaces.NumberTypeInt64},
+		}
+		mockRepo.On("Transact").Return(numbers, nil)
+		stream := importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_MAX_WINS, false,
+			&api_v1.Counter{Name: "balance", Type: api_v1.CounterType_COUNTER_TYPE_INT64, TypedValue: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Int64Value{Int64Value: -2}}},
+			&api_v1.Counter{Name: "load", Type: api_v1.CounterType_COUNTER_TYPE_FLOAT64, TypedValue: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Float64Value{Float64Value: 0.5}}},
+		)
+
+		err := service.Import(stream)
+
+		require.NoError(t, err)
+		assert.Equal(t, api_v1.ImportAction_IMPORT_ACTION_OVERWRITTEN, stream.response.Results[0].Action, "-2 is above -5")
+		assert.Equal(t, int64(-5), stream.response.Results[0].TypedPreviousValue.GetInt64Value())
+		assert.Equal(t, int64(-2), stream.response.Results[0].TypedValue.GetInt64Value())
+		assert.Equal(t, int64(-2), int64(numbers["balance"].Number))
+		assert.Equal(t, interfaces.NumberTypeFloat64, numbers["load"].Type)
+		assert.Equal(t, 0.5, math.Float64frombits(numbers["load"].Number))
+	})
+
+	t.Run("distinct counters are imported from their sketch", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		numbers := map[string]interfaces.Number{}
+		mockRepo.On("Transact").Return(numbers, nil)
+		exported := distinctNumber("visitors", 12, 0, 100)
+		stream := importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_OVERWRITE, false,
+			&api_v1.Counter{Name: "visitors", Type: api_v1.CounterType_COUNTER_TYPE_DISTINCT, TypedValue: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Uint64Value{Uint64Value: 7}}, Sketch: exported.Sketch},
+		)
+
+		err := service.Import(stream)
+
+		require.NoError(t, err)
+		assert.Equal(t, exported.Number, stream.response.Results[0].TypedValue.GetUint64Value(), "the estimate comes from the sketch")
+		assert.Equal(t, interfaces.NumberTypeDistinct, numbers["visitors"].Type)
+		assert.Equal(t, exported.Sketch, numbers["visitors"].Sketch)
+		assert.Equal(t, exported.Number, numbers["visitors"].Number)
+	})
+
+	t.Run("max wins across types is a failed precondition", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Transact").Return(stored(), nil)
+		stream := importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_MAX_WINS, false,
+			&api_v1.Counter{Name: "low", Type: api_v1.CounterType_COUNTER_TYPE_INT64, TypedValue: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Int64Value{Int64Value: 9}}},
+		)
+
+		err := service.Import(stream)
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("no counters", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		stream := importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP, false)
+
+		err := service.Import(stream)
+
+		require.NoError(t, err)
+		assert.Empty(t, stream.response.Results)
+		mockRepo.AssertNotCalled(t, "Transact")
+	})
+
+	invalid := []struct {
+		name   string
+		stream *fakeImportStream
+		index  string
+	}{
+		{name: "empty stream", stream: &fakeImportStream{}},
+		{name: "missing options", stream: &fakeImportStream{requests: []*api_v1.ImportRequest{
+			{Item: &api_v1.ImportRequest_Counter{Counter: &api_v1.Counter{Name: "a"}}},
+		}}},
+		{name: "unspecified mode", stream: importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_UNSPECIFIED, false)},
+		{name: "options twice", stream: &fakeImportStream{requests: []*api_v1.ImportRequest{
+			{Item: &api_v1.ImportRequest_Options{Options: &api_v1.ImportOptions{ConflictMode: api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP}}},
+			{Item: &api_v1.ImportRequest_Options{Options: &api_v1.ImportOptions{ConflictMode: api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP}}},
+		}}, index: "0"},
+		{name: "invalid name", stream: importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP, false,
+			&api_v1.Counter{Name: "a"}, &api_v1.Counter{Name: "bad name"}), index: "1"},
+		{name: "duplicate name", stream: importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP, false,
+			&api_v1.Counter{Name: "a"}, &api_v1.Counter{Name: "b"}, &api_v1.Counter{Name: "a"}), index: "2"},
+		{name: "value outside bounds", stream: importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP, false,
+			&api_v1.Counter{Name: "a", Value: 11, Bounds: &api_v1.Bounds{Max: proto.Uint64(10)}}), index: "0"},
+		{name: "typed value of another type", stream: importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP, false,
+			&api_v1.Counter{Name: "a", Type: api_v1.CounterType_COUNTER_TYPE_INT64, TypedValue: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Uint64Value{Uint64Value: 1}}}), index: "0"},
+		{name: "typed counter without typed value", stream: importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP, false,
+			&api_v1.Counter{Name: "a", Type: api_v1.CounterType_COUNTER_TYPE_FLOAT64}), index: "0"},
+		{name: "distinct counter without sketch", stream: importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP, false,
+			&api_v1.Counter{Name: "a", Type: api_v1.CounterType_COUNTER_TYPE_DISTINCT}), index: "0"},
+		{name: "sketch on a uint64 counter", stream: importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP, false,
+			&api_v1.Counter{Name: "a", Sketch: newSketch(minDistinctPrecision).encode()}), index: "0"},
+		{name: "invalid label", stream: importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP, false,
+			&api_v1.Counter{Name: "a", Labels: map[string]string{"Bad Key": "x"}}), index: "0"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+
+			err := service.Import(tt.stream)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, tt.stream.response)
+			mockRepo.AssertNotCalled(t, "Transact")
+			if tt.index != "" {
+				details := status.Convert(err).Details()
+				require.Len(t, details, 1)
+				info, ok := details[0].(*errdetails.ErrorInfo)
+				require.True(t, ok)
+				assert.Equal(t, importErrorReason, info.Reason)
+				assert.Equal(t, tt.index, info.Metadata["index"])
+			}
+		})
+	}
+
+	t.Run("counters saved before versions were tracked exist", func(t *testing.T) {
+		for _, mode := range []api_v1.ImportConflictMode{
+			api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP,
+			api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_MAX_WINS,
+		} {
+			repo := legacyRepository(t, map[string]uint64{"orders": 500})
+			service := NewIncrementService(repo, "default")
+			stream := importStream(mode, false, &api_v1.Counter{Name: "orders", Value: 1})
+
+			require.NoError(t, service.Import(stream))
+
+			assert.Equal(t, api_v1.ImportAction_IMPORT_ACTION_SKIPPED, stream.response.Results[0].Action, mode)
+			stored, err := repo.FindByID("orders")
+			require.NoError(t, err)
+			assert.Equal(t, uint64(500), stored.Number, mode)
+		}
+	})
+
+	t.Run("exported versions are restored and never go back", func(t *testing.T) {
+		source := legacyRepository(t, nil)
+		for i := 0; i < 3; i++ {
+			_, err := source.Update("orders", func(number *interfaces.Number) error {
+				number.Number++
+				return nil
+			})
+			require.NoError(t, err)
+		}
+		exported := &fakeExportStream{ctx: context.Background()}
+		require.NoError(t, NewIncrementService(source, "default").Export(&api_v1.ExportRequest{}, exported))
+		require.Len(t, exported.responses, 1)
+		counter := exported.responses[0].Counter
+		require.Equal(t, uint64(3), counter.Version)
+
+		repo := legacyRepository(t, map[string]uint64{"ahead": 1})
+		for i := 0; i < 5; i++ {
+			_, err := repo.Update("ahead", func(number *interfaces.Number) error {
+				number.Number++
+				return nil
+			})
+			require.NoError(t, err)
+		}
+		service := NewIncrementService(repo, "default")
+		behind := proto.Clone(counter).(*api_v1.Counter)
+		behind.Name = "ahead"
+
+		require.NoError(t, service.Import(importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_OVERWRITE, false, counter, behind)))
+
+		restored, err := repo.FindByID("orders")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), restored.Version)
+		assert.Equal(t, uint64(3), restored.Number)
+		ahead, err := repo.FindByID("ahead")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), ahead.Version)
+		assert.Equal(t, uint64(3), ahead.Number)
+	})
+
+	t.Run("failed chunk reports the counters written before it", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Transact").Return(map[string]interfaces.Number{}, nil).Once()
+		mockRepo.On("Transact").Return(nil, &interfaces.ConflictError{ID: "b"}).Once()
+		counters := make([]*api_v1.Counter, importChunkSize+1)
+		for i := range counters {
+			counters[i] = &api_v1.Counter{Name: fmt.Sprintf("c%d", i), Value: 1}
+		}
+
+		err := service.Import(importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_OVERWRITE, false, counters...))
+
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		details := status.Convert(err).Details()
+		require.Len(t, details, 2)
+		info, ok := details[0].(*errdetails.ErrorInfo)
+		require.True(t, ok)
+		assert.Equal(t, importIncompleteReason, info.Reason)
+		assert.Equal(t, strconv.Itoa(importChunkSize), info.Metadata["index"])
+		written, ok := details[1].(*api_v1.ImportResponse)
+		require.True(t, ok)
+		assert.Len(t, written.Results, importChunkSize)
+		assert.Equal(t, uint32(importChunkSize), written.Created)
+	})
+
+	t.Run("repository error is internal", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Transact").Return(nil, errors.New("disk full"))
+
+		err := service.Import(importStream(api_v1.ImportConflictMode_IMPORT_CONFLICT_MODE_SKIP, false, &api_v1.Counter{Name: "a"}))
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
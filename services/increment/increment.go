@@ -3,48 +3,92 @@ package increment
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
 	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
 )
 
+// defaultIdempotencyTTL is how long idempotency records are kept unless configured otherwise
+const defaultIdempotencyTTL = 24 * time.Hour
+
 // ServiceImpl is the implementation of IncrementServiceServer
 type ServiceImpl struct {
 	api_v1.UnimplementedIncrementServiceServer
-	repo   interfaces.INumberRepository
-	bucket string
+	repo              interfaces.INumberRepository
+	bucket            string
+	idempotencyTTL    time.Duration
+	distinctPrecision uint8
+}
+
+// Option configures optional ServiceImpl behaviour
+type Option func(s *ServiceImpl)
+
+// WithIdempotencyTTL sets how long idempotency records are kept
+// - ttl: time.Duration record lifetime, 0 keeps records forever
+func WithIdempotencyTTL(ttl time.Duration) Option {
+	return func(s *ServiceImpl) {
+		s.idempotencyTTL = ttl
+	}
+}
+
+// WithDistinctPrecision sets the precision of distinct counters created without one
+// - precision: uint8 sketch precision, clamped to [minDistinctPrecision, maxDistinctPrecision]
+func WithDistinctPrecision(precision uint8) Option {
+	return func(s *ServiceImpl) {
+		s.distinctPrecision = min(max(precision, minDistinctPrecision), maxDistinctPrecision)
+	}
 }
 
 // NewIncrementService creates a new ServiceImpl
 // - repo: INumberRepository number repository
-// - bucket: string bucket name
-func NewIncrementService(repo interfaces.INumberRepository, bucket string) *ServiceImpl {
-	return &ServiceImpl{
-		repo:   repo,
-		bucket: bucket,
+// - bucket: string default bucket name used when a request does not name a counter
+// - opts: ...Option optional settings
+func NewIncrementService(repo interfaces.INumberRepository, bucket string, opts ...Option) *ServiceImpl {
+	s := &ServiceImpl{
+		repo:              repo,
+		bucket:            bucket,
+		idempotencyTTL:    defaultIdempotencyTTL,
+		distinctPrecision: defaultDistinctPrecision,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// Increment increments the number in the bucket
+// Increment increments the named counter, or the default bucket when no name is given
 // - ctx: context.Context context
 // - req: *api_v1.IncrementRequest request
 // Returns *api_v1.IncrementResponse response
 func (s *ServiceImpl) Increment(ctx context.Context, req *api_v1.IncrementRequest) (*api_v1.IncrementResponse, error) {
-	number, err := s.repo.FindByID(s.bucket)
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	key, err := idempotencyKey(ctx, req.GetIdempotencyKey())
 	if err != nil {
-		slog.Info("Bucket not found, creating new bucket", "bucket", s.bucket)
-		number = &interfaces.Number{ID: s.bucket, Number: 0}
+		return nil, err
 	}
-	slog.Info("Incrementing number", "number", number.Number)
-	number.Number++
-	slog.Info("Saving number", "number", number.Number)
-	saveErr := s.repo.Save(*number)
-	if saveErr != nil {
-		slog.Error("Error saving number", "error", saveErr)
-		return nil, saveErr
+	expiry, err := fromExpiry(req.GetExpiry())
+	if err != nil {
+		return nil, err
+	}
+	number, err := s.mutate(name, key, requestFingerprint("increment", expiry), attributed(ctx, func(number *interfaces.Number) error {
+		slog.Info("Incrementing number", "bucket", name, "number", number.Number)
+		setExpiry(number, expiry)
+		return applyDelta(number, 1)
+	}))
+	if err != nil {
+		slog.Error("Error incrementing number", "bucket", name, "error", err)
+		return nil, rpc.ToStatus(err)
 	}
 
-	resp := &api_v1.IncrementResponse{Value: number.Number}
+	resp := &api_v1.IncrementResponse{TypedValue: toTypedValue(number)}
+	if number.Type == interfaces.NumberTypeUint64 {
+		resp.Value = number.Number
+	}
 	slog.Info("Returning incremented number", "number", resp.Value)
 	return resp, nil
 }
@@ -2,12 +2,20 @@ package increment
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
 	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/repositories/number"
+	"github.com/dgraph-io/badger/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // MockNumberRepository is a mock implementation of the INumberRepository interface
@@ -16,8 +24,8 @@ type MockNumberRepository struct {
 }
 
 // DeleteByID implements interfaces.INumberRepository.
-func (m *MockNumberRepository) DeleteByID(id string) error {
-	args := m.Called(id)
+func (m *MockNumberRepository) DeleteByID(id string, source interfaces.ChangeSource) error {
+	args := m.Called(id, source)
 	return args.Error(0)
 }
 
@@ -31,6 +39,232 @@ func (m *MockNumberRepository) Save(number interfaces.Number) error {
 	return args.Error(0)
 }
 
+// Update implements interfaces.INumberRepository, the first return value is
+// the stored number passed to fn, it is returned after fn modifies it
+func (m *MockNumberRepository) Update(id string, fn func(number *interfaces.Number) error) (*interfaces.Number, error) {
+	args := m.Called(id)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+	number := args.Get(0).(*interfaces.Number)
+	if err := fn(number); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+// DeleteIf implements interfaces.INumberRepository, the first return value is
+// the stored number passed to check, it is returned when check passes
+func (m *MockNumberRepository) DeleteIf(id string, check func(number *interfaces.Number) error) (*interfaces.Number, error) {
+	args := m.Called(id)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+	number := args.Get(0).(*interfaces.Number)
+	if err := check(number); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+func (m *MockNumberRepository) Buckets(id string, resolution time.Duration, from time.Time, to time.Time) ([]interfaces.WindowBucket, error) {
+	args := m.Called(id, resolution, from, to)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+	return args.Get(0).([]interfaces.WindowBucket), nil
+}
+
+func (m *MockNumberRepository) History(id string, from time.Time, to time.Time, after *interfaces.HistoryEntry, limit int) ([]interfaces.HistoryEntry, error) {
+	args := m.Called(id, from, to, after, limit)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+	return args.Get(0).([]interfaces.HistoryEntry), nil
+}
+
+func (m *MockNumberRepository) FindByIDAt(id string, revision uint64) (*interfaces.Number, error) {
+	args := m.Called(id, revision)
+	if err := args.Error(1); err != nil {
+		return nil, err
+	}
+	return args.Get(0).(*interfaces.Number), nil
+}
+
+func (m *MockNumberRepository) ListAt(prefix string, after string, limit int, revision uint64) ([]interfaces.Number, uint64, error) {
+	args := m.Called(prefix, after, limit, revision)
+	if err := args.Error(2); err != nil {
+		return nil, 0, err
+	}
+	return args.Get(0).([]interfaces.Number), args.Get(1).(uint64), nil
+}
+
+// ForEach implements interfaces.INumberRepository, the first return value is
+// the numbers passed to fn and the second the revision they are passed with
+func (m *MockNumberRepository) ForEach(prefix string, fn func(number interfaces.Number, revision uint64) error) error {
+	args := m.Called(prefix)
+	if err := args.Error(2); err != nil {
+		return err
+	}
+	for _, number := range args.Get(0).([]interfaces.Number) {
+		if err := fn(number, args.Get(1).(uint64)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachLabeled implements interfaces.INumberRepository, the first return value is
+// the numbers passed to fn and the second the revision they are passed with
+func (m *MockNumberRepository) ForEachLabeled(key string, value *string, fn func(number interfaces.Number, revision uint64) error) error {
+	args := m.Called(key, value)
+	if err := args.Error(2); err != nil {
+		return err
+	}
+	for _, number := range args.Get(0).([]interfaces.Number) {
+		if err := fn(number, args.Get(1).(uint64)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockNumberRepository) List(prefix string, after string, limit int) ([]interfaces.Number, error) {
+	args := m.Called(prefix, after, limit)
+	return args.Get(0).([]interfaces.Number), args.Error(1)
+}
+
+func (m *MockNumberRepository) ListIDs(prefix string, after string, limit int) ([]string, error) {
+	args := m.Called(prefix, after, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// Watch implements interfaces.INumberRepository, it sends the events given as the
+// first return value and then blocks until ctx is done
+func (m *MockNumberRepository) Watch(ctx context.Context, prefix string, since uint64, fn func(event interfaces.NumberEvent) error) error {
+	args := m.Called(prefix, since)
+	for _, event := range args.Get(0).([]interfaces.NumberEvent) {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (m *MockNumberRepository) TopK(prefix string, k int) ([]interfaces.RankEntry, uint64, error) {
+	args := m.Called(prefix, k)
+	if err := args.Error(2); err != nil {
+		return nil, 0, err
+	}
+	return args.Get(0).([]interfaces.RankEntry), args.Get(1).(uint64), nil
+}
+
+func (m *MockNumberRepository) Rank(id string, prefix string) (*interfaces.RankEntry, uint64, error) {
+	args := m.Called(id, prefix)
+	if err := args.Error(2); err != nil {
+		return nil, 0, err
+	}
+	return args.Get(0).(*interfaces.RankEntry), args.Get(1).(uint64), nil
+}
+
+// WatchTopK implements interfaces.INumberRepository, fn is called once for every leaderboard
+// in the first return value with its index as revision
+func (m *MockNumberRepository) WatchTopK(ctx context.Context, prefix string, k int, fn func(entries []interfaces.RankEntry, revision uint64) error) error {
+	args := m.Called(prefix, k)
+	for i, entries := range args.Get(0).([][]interfaces.RankEntry) {
+		if err := fn(entries, uint64(i)+1); err != nil {
+			return err
+		}
+	}
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (m *MockNumberRepository) Migrate() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// Transact implements interfaces.INumberRepository, fn runs against a mockNumberTxn
+// seeded with the numbers in the first return value
+func (m *MockNumberRepository) Transact(fn func(txn interfaces.INumberTxn) error) error {
+	args := m.Called()
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	return fn(&mockNumberTxn{numbers: args.Get(0).(map[string]interfaces.Number), records: map[string]interfaces.IdempotencyRecord{}})
+}
+
+// mockNumberTxn is an in memory interfaces.INumberTxn
+type mockNumberTxn struct {
+	numbers map[string]interfaces.Number
+	records map[string]interfaces.IdempotencyRecord
+}
+
+func (t *mockNumberTxn) Get(id string) (*interfaces.Number, error) {
+	number, ok := t.numbers[id]
+	if !ok {
+		number = interfaces.Number{ID: id}
+	}
+	return &number, nil
+}
+
+func (t *mockNumberTxn) Put(number *interfaces.Number) error {
+	number.Version++
+	t.numbers[number.ID] = *number
+	return nil
+}
+
+func (t *mockNumberTxn) GetIdempotency(id string, key string) (*interfaces.IdempotencyRecord, error) {
+	record, ok := t.records[id+"/"+key]
+	if !ok {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+func (t *mockNumberTxn) PutIdempotency(id string, key string, record interfaces.IdempotencyRecord, ttl time.Duration) error {
+	t.records[id+"/"+key] = record
+	return nil
+}
+
+func (t *mockNumberTxn) GetLease(name string) (*interfaces.Lease, error) {
+	return nil, nil
+}
+
+func (t *mockNumberTxn) PutLease(lease *interfaces.Lease, ttl time.Duration) error {
+	return nil
+}
+
+func (t *mockNumberTxn) DeleteLease(name string) error {
+	return nil
+}
+
+// legacyRepository opens a badger backed repository holding numbers in the shape the
+// first releases saved them, without a version or any later field
+func legacyRepository(t *testing.T, numbers map[string]uint64) interfaces.INumberRepository {
+	t.Helper()
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()).WithLogger(nil))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, db.Update(func(txn *badger.Txn) error {
+		for id, value := range numbers {
+			if err := txn.Set([]byte(id), []byte(fmt.Sprintf(`{"ID":%q,"Number":%d}`, id, value))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	return number.NewBadgerNumberRepository(db)
+}
+
 func TestNewIncrementService(t *testing.T) {
 	mockRepo := new(MockNumberRepository)
 	bucket := "test-bucket"
@@ -39,6 +273,10 @@ func TestNewIncrementService(t *testing.T) {
 	assert.NotNil(t, service)
 	assert.Equal(t, mockRepo, service.repo)
 	assert.Equal(t, bucket, service.bucket)
+	assert.Equal(t, defaultIdempotencyTTL, service.idempotencyTTL)
+
+	service = NewIncrementService(mockRepo, bucket, WithIdempotencyTTL(time.Minute))
+	assert.Equal(t, time.Minute, service.idempotencyTTL)
 }
 
 func TestIncrement(t *testing.T) {
@@ -47,16 +285,13 @@ func TestIncrement(t *testing.T) {
 		mockRepo := new(MockNumberRepository)
 		bucket := "test-bucket-1"
 		service := NewIncrementService(mockRepo, bucket)
-		mockNumber := &interfaces.Number{ID: bucket, Number: 1}
-		expectedNumber := &interfaces.Number{ID: bucket, Number: 2}
-		mockRepo.On("FindByID", bucket).Return(mockNumber, nil)
-		mockRepo.On("Save", *expectedNumber).Return(nil)
+		mockRepo.On("Update", bucket).Return(&interfaces.Number{ID: bucket, Number: 1}, nil)
 
 		req := &api_v1.IncrementRequest{}
 		resp, err := service.Increment(context.Background(), req)
 
 		assert.NoError(t, err)
-		assert.Equal(t, expectedNumber.Number, resp.Value)
+		assert.Equal(t, uint64(2), resp.Value)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -64,8 +299,7 @@ func TestIncrement(t *testing.T) {
 		mockRepo := new(MockNumberRepository)
 		bucket := "test-bucket-2"
 		service := NewIncrementService(mockRepo, bucket)
-		mockRepo.On("FindByID", bucket).Return(&interfaces.Number{}, interfaces.ErrNotFound)
-		mockRepo.On("Save", mock.AnythingOfType("interfaces.Number")).Return(nil)
+		mockRepo.On("Update", bucket).Return(&interfaces.Number{ID: bucket}, nil)
 
 		req := &api_v1.IncrementRequest{}
 		resp, err := service.Increment(context.Background(), req)
@@ -79,9 +313,7 @@ func TestIncrement(t *testing.T) {
 		mockRepo := new(MockNumberRepository)
 		bucket := "test-bucket-3"
 		service := NewIncrementService(mockRepo, bucket)
-		mockNumber := &interfaces.Number{ID: bucket, Number: 1}
-		mockRepo.On("FindByID", bucket).Return(mockNumber, nil)
-		mockRepo.On("Save", mock.Anything).Return(interfaces.ErrSaveFailed)
+		mockRepo.On("Update", bucket).Return(nil, interfaces.ErrSaveFailed)
 
 		req := &api_v1.IncrementRequest{}
 		resp, err := service.Increment(context.Background(), req)
@@ -90,4 +322,58 @@ func TestIncrement(t *testing.T) {
 		assert.Nil(t, resp)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("conflict error", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		bucket := "test-bucket-6"
+		service := NewIncrementService(mockRepo, bucket)
+		mockRepo.On("Update", bucket).Return(nil, &interfaces.ConflictError{ID: bucket, Attempts: 10})
+
+		req := &api_v1.IncrementRequest{}
+		resp, err := service.Increment(context.Background(), req)
+
+		assert.Error(t, err)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		assert.Nil(t, resp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("overflow is out of range", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		bucket := "test-bucket-7"
+		service := NewIncrementService(mockRepo, bucket)
+		mockRepo.On("Update", bucket).Return(&interfaces.Number{ID: bucket, Number: math.MaxUint64}, nil)
+
+		req := &api_v1.IncrementRequest{}
+		resp, err := service.Increment(context.Background(), req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("increment named counter", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "test-bucket-4")
+		mockRepo.On("Update", "orders").Return(&interfaces.Number{ID: "orders", Number: 5}, nil)
+
+		req := &api_v1.IncrementRequest{Name: "orders"}
+		resp, err := service.Increment(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(6), resp.Value)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "test-bucket-5")
+
+		req := &api_v1.IncrementRequest{Name: "not a valid name"}
+		resp, err := service.Increment(context.Background(), req)
+
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
 }
@@ -0,0 +1,134 @@
+package increment
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultTopK is the number of counters returned when a request does not set k
+	defaultTopK = 10
+	// maxTopK caps the number of counters a single leaderboard read returns
+	maxTopK = 1000
+)
+
+// checkLeaderboard validates the prefix naming a leaderboard
+// - prefix: string requested prefix, empty ranks every counter
+// Returns InvalidArgument if the prefix is not a valid counter name prefix ending in a slash
+func checkLeaderboard(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if !strings.HasSuffix(prefix, "/") || !rpc.ValidName(prefix) {
+		return status.Errorf(codes.InvalidArgument, "invalid leaderboard prefix %q, it must end with a slash", prefix)
+	}
+	return nil
+}
+
+// topK resolves the number of counters a leaderboard read returns
+// - k: uint32 requested number, 0 selects the default
+// Returns int the number, capped at maxTopK
+func topK(k uint32) int {
+	if k == 0 {
+		return defaultTopK
+	}
+	return int(min(k, maxTopK))
+}
+
+// toTopKResponse converts rank index entries to the API leaderboard
+// - entries: []interfaces.RankEntry entries in descending value order
+// - revision: uint64 revision the entries were read at
+// Returns *api_v1.TopKResponse the leaderboard
+func toTopKResponse(entries []interfaces.RankEntry, revision uint64) *api_v1.TopKResponse {
+	resp := &api_v1.TopKResponse{Entries: make([]*api_v1.LeaderboardEntry, 0, len(entries)), ReadRevision: revision}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, toLeaderboardEntry(entry))
+	}
+	return resp
+}
+
+// toLeaderboardEntry converts a rank index entry to the API entry
+// - entry: interfaces.RankEntry entry to convert
+// Returns *api_v1.LeaderboardEntry the API entry
+func toLeaderboardEntry(entry interfaces.RankEntry) *api_v1.LeaderboardEntry {
+	return &api_v1.LeaderboardEntry{Name: entry.ID, Value: entry.Value, Rank: entry.Rank}
+}
+
+// TopK returns the highest uint64 counters under a prefix from the rank index
+// - ctx: context.Context context
+// - req: *api_v1.TopKRequest request
+// Returns *api_v1.TopKResponse the counters in descending value order, or InvalidArgument
+// for a malformed prefix
+func (s *ServiceImpl) TopK(ctx context.Context, req *api_v1.TopKRequest) (*api_v1.TopKResponse, error) {
+	prefix := req.GetPrefix()
+	if err := checkLeaderboard(prefix); err != nil {
+		return nil, err
+	}
+	entries, revision, err := s.repo.TopK(prefix, topK(req.GetK()))
+	if err != nil {
+		slog.Error("Error reading leaderboard", "prefix", prefix, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	return toTopKResponse(entries, revision), nil
+}
+
+// Rank returns the position of a counter on a leaderboard
+// - ctx: context.Context context
+// - req: *api_v1.RankRequest request
+// Returns *api_v1.RankResponse the entry of the counter, InvalidArgument if the prefix is
+// malformed or does not hold the counter, NotFound if the counter does not exist,
+// FailedPrecondition if it is not a uint64 counter, or OutOfRange if too many counters rank
+// above it to count
+func (s *ServiceImpl) Rank(ctx context.Context, req *api_v1.RankRequest) (*api_v1.RankResponse, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	// the leaderboard defaults to the directory holding the counter
+	prefix := name[:strings.LastIndex(name, "/")+1]
+	if req.Prefix != nil {
+		prefix = req.GetPrefix()
+		if err := checkLeaderboard(prefix); err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(name, prefix) {
+			return nil, status.Errorf(codes.InvalidArgument, "counter %q is not under prefix %q", name, prefix)
+		}
+	}
+	entry, revision, err := s.repo.Rank(name, prefix)
+	if err != nil {
+		return nil, rpc.ToStatus(err)
+	}
+	return &api_v1.RankResponse{Entry: toLeaderboardEntry(*entry), ReadRevision: revision}, nil
+}
+
+// WatchTopK streams the highest uint64 counters under a prefix, then the new leaderboard every
+// time it changes, changes arriving while a send blocks are folded into the next leaderboard
+// - req: *api_v1.TopKRequest request
+// - stream: grpc.ServerStreamingServer[api_v1.TopKResponse] stream to send leaderboards on
+// Returns nil when the client goes away, InvalidArgument for a malformed prefix, or an error
+// if watching fails
+func (s *ServiceImpl) WatchTopK(req *api_v1.TopKRequest, stream grpc.ServerStreamingServer[api_v1.TopKResponse]) error {
+	prefix := req.GetPrefix()
+	if err := checkLeaderboard(prefix); err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	slog.Info("Leaderboard watch started", "prefix", prefix, "caller", callerIdentity(ctx))
+	err := s.repo.WatchTopK(ctx, prefix, topK(req.GetK()), func(entries []interfaces.RankEntry, revision uint64) error {
+		return stream.Send(toTopKResponse(entries, revision))
+	})
+	if err != nil && ctx.Err() == nil {
+		slog.Error("Leaderboard watch failed", "prefix", prefix, "error", err)
+		return rpc.ToStatus(err)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package increment
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeTopKStream records the leaderboards sent on a WatchTopK stream
+type fakeTopKStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	mu     sync.Mutex
+	boards []*api_v1.TopKResponse
+}
+
+func (f *fakeTopKStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeTopKStream) Send(resp *api_v1.TopKResponse) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.boards = append(f.boards, resp)
+	return nil
+}
+
+func (f *fakeTopKStream) sent() []*api_v1.TopKResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*api_v1.TopKResponse{}, f.boards...)
+}
+
+func TestTopK(t *testing.T) {
+	t.Run("returns the leaderboard", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("TopK", "game/", 3).Return([]interfaces.RankEntry{
+			{ID: "game/bob", Value: 50, Rank: 1},
+			{ID: "game/alice", Value: 30, Rank: 2},
+		}, uint64(7), nil)
+
+		resp, err := service.TopK(context.Background(), &api_v1.TopKRequest{Prefix: "game/", K: 3})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 2)
+		assert.Equal(t, "game/bob", resp.Entries[0].Name)
+		assert.Equal(t, uint64(50), resp.Entries[0].Value)
+		assert.Equal(t, uint64(2), resp.Entries[1].Rank)
+		assert.Equal(t, uint64(7), resp.ReadRevision)
+	})
+
+	for _, tt := range []struct {
+		name string
+		k    uint32
+		want int
+	}{
+		{name: "k defaults", k: 0, want: defaultTopK},
+		{name: "k is capped", k: maxTopK + 1, want: maxTopK},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+			mockRepo.On("TopK", "", tt.want).Return([]interfaces.RankEntry{}, uint64(1), nil)
+
+			resp, err := service.TopK(context.Background(), &api_v1.TopKRequest{K: tt.k})
+
+			require.NoError(t, err)
+			assert.Empty(t, resp.Entries)
+		})
+	}
+
+	for _, prefix := range []string{"game", "-game/", "/"} {
+		t.Run("invalid prefix "+prefix, func(t *testing.T) {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+
+			_, err := service.TopK(context.Background(), &api_v1.TopKRequest{Prefix: prefix})
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			mockRepo.AssertNotCalled(t, "TopK", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	prefix := func(p string) *string { return &p }
+
+	for _, tt := range []struct {
+		name   string
+		req    *api_v1.RankRequest
+		id     string
+		prefix string
+	}{
+		{name: "defaults to the directory of the counter", req: &api_v1.RankRequest{Name: "game/eu/alice"}, id: "game/eu/alice", prefix: "game/eu/"},
+		{name: "top level counters rank among every counter", req: &api_v1.RankRequest{Name: "score"}, id: "score", prefix: ""},
+		{name: "explicit prefix", req: &api_v1.RankRequest{Name: "game/eu/alice", Prefix: prefix("game/")}, id: "game/eu/alice", prefix: "game/"},
+		{name: "explicit empty prefix", req: &api_v1.RankRequest{Name: "game/eu/alice", Prefix: prefix("")}, id: "game/eu/alice", prefix: ""},
+		{name: "default counter", req: &api_v1.RankRequest{}, id: "default", prefix: ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+			mockRepo.On("Rank", tt.id, tt.prefix).Return(&interfaces.RankEntry{ID: tt.id, Value: 30, Rank: 2}, uint64(9), nil)
+
+			resp, err := service.Rank(context.Background(), tt.req)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.id, resp.Entry.Name)
+			assert.Equal(t, uint64(2), resp.Entry.Rank)
+			assert.Equal(t, uint64(9), resp.ReadRevision)
+		})
+	}
+
+	errs := []struct {
+		name string
+		req  *api_v1.RankRequest
+		err  error
+		code codes.Code
+	}{
+		{name: "prefix not holding the counter", req: &api_v1.RankRequest{Name: "game/alice", Prefix: prefix("other/")}, code: codes.InvalidArgument},
+		{name: "prefix without a slash", req: &api_v1.RankRequest{Name: "game/alice", Prefix: prefix("game")}, code: codes.InvalidArgument},
+		{name: "invalid name", req: &api_v1.RankRequest{Name: "-game"}, code: codes.InvalidArgument},
+		{name: "missing counter", req: &api_v1.RankRequest{Name: "game/alice"}, err: interfaces.ErrNotFound, code: codes.NotFound},
+		{name: "counter of another type", req: &api_v1.RankRequest{Name: "game/alice"}, err: interfaces.ErrTypeMismatch, code: codes.FailedPrecondition},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+			mockRepo.On("Rank", mock.Anything, mock.Anything).Return(nil, uint64(0), tt.err)
+
+			_, err := service.Rank(context.Background(), tt.req)
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestWatchTopK(t *testing.T) {
+	t.Run("sends every leaderboard", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("WatchTopK", "game/", defaultTopK).Return([][]interfaces.RankEntry{
+			{{ID: "game/alice", Value: 30, Rank: 1}},
+			{{ID: "game/bob", Value: 40, Rank: 1}, {ID: "game/alice", Value: 30, Rank: 2}},
+		}, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &fakeTopKStream{ctx: ctx}
+		done := make(chan error, 1)
+		go func() { done <- service.WatchTopK(&api_v1.TopKRequest{Prefix: "game/"}, stream) }()
+
+		require.Eventually(t, func() bool { return len(stream.sent()) == 2 }, time.Second, 10*time.Millisecond)
+		boards := stream.sent()
+		assert.Equal(t, "game/alice", boards[0].Entries[0].Name)
+		assert.Len(t, boards[1].Entries, 2)
+		assert.Equal(t, uint64(2), boards[1].ReadRevision)
+
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("repository failure is reported", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("WatchTopK", "", defaultTopK).Return([][]interfaces.RankEntry{}, errors.New("disk failed"))
+
+		err := service.WatchTopK(&api_v1.TopKRequest{}, &fakeTopKStream{ctx: context.Background()})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("invalid prefix", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		err := service.WatchTopK(&api_v1.TopKRequest{Prefix: "game"}, &fakeTopKStream{ctx: context.Background()})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockRepo.AssertNotCalled(t, "WatchTopK", mock.Anything, mock.Anything)
+	})
+}
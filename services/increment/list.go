@@ -0,0 +1,121 @@
+package increment
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultPageSize is used when a list request does not set a page size
+	defaultPageSize = 100
+	// maxPageSize caps the page size a list request can ask for
+	maxPageSize = 1000
+)
+
+// pageToken is the decoded form of the opaque page token handed to clients,
+// it resumes after the last returned name so concurrent writes do not shift pages
+type pageToken struct {
+	// Prefix is the prefix filter the token was issued for
+	Prefix string `json:"p"`
+	// After is the last counter name returned on the previous page
+	After string `json:"a"`
+	// Revision is the read revision the token was issued for, 0 for the latest state
+	Revision uint64 `json:"r,omitempty"`
+}
+
+// encodePageToken encodes a page token as an opaque string
+// - token: pageToken token to encode
+// Returns the url safe base64 encoded token
+func encodePageToken(token pageToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken decodes and validates an opaque page token
+// - raw: string token from the request, empty starts at the first page
+// - prefix: string prefix filter of the request
+// - revision: uint64 read revision of the request
+// Returns the decoded token, or InvalidArgument if it is malformed or was issued for another prefix or revision
+func decodePageToken(raw string, prefix string, revision uint64) (pageToken, error) {
+	token := pageToken{Prefix: prefix, Revision: revision}
+	if raw == "" {
+		return token, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return token, status.Error(codes.InvalidArgument, "malformed page token")
+	}
+	if err := json.Unmarshal(data, &token); err != nil || !rpc.ValidName(token.After) {
+		return token, status.Error(codes.InvalidArgument, "malformed page token")
+	}
+	if token.Prefix != prefix {
+		return token, status.Error(codes.InvalidArgument, "page token was issued for a different prefix")
+	}
+	if token.Revision != revision {
+		return token, status.Error(codes.InvalidArgument, "page token was issued for a different read revision")
+	}
+	return token, nil
+}
+
+// ListCounters lists counters in name order one page at a time, as of a read revision when one is given
+// - ctx: context.Context context
+// - req: *api_v1.ListCountersRequest request
+// Returns *api_v1.ListCountersResponse page of counters, InvalidArgument for a bad prefix, page size or token,
+// or OutOfRange if the read revision is no longer retained
+func (s *ServiceImpl) ListCounters(ctx context.Context, req *api_v1.ListCountersRequest) (*api_v1.ListCountersResponse, error) {
+	prefix := req.GetPrefix()
+	if prefix != "" && !rpc.ValidName(prefix) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid prefix %q", prefix)
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page size must not be negative")
+	}
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+	token, err := decodePageToken(req.GetPageToken(), prefix, req.GetReadRevision())
+	if err != nil {
+		return nil, err
+	}
+
+	// read one extra entry to learn whether another page follows
+	resp := &api_v1.ListCountersResponse{}
+	more := false
+	if req.GetNamesOnly() && req.GetReadRevision() == 0 {
+		ids, err := s.repo.ListIDs(prefix, token.After, pageSize+1)
+		if err != nil {
+			return nil, rpc.ToStatus(err)
+		}
+		more = len(ids) > pageSize
+		for _, id := range ids[:min(len(ids), pageSize)] {
+			resp.Counters = append(resp.Counters, &api_v1.Counter{Name: id})
+		}
+	} else {
+		numbers, revision, err := s.repo.ListAt(prefix, token.After, pageSize+1, req.GetReadRevision())
+		if err != nil {
+			return nil, rpc.ToStatus(err)
+		}
+		resp.ReadRevision = revision
+		more = len(numbers) > pageSize
+		for i := range numbers[:min(len(numbers), pageSize)] {
+			if req.GetNamesOnly() {
+				resp.Counters = append(resp.Counters, &api_v1.Counter{Name: numbers[i].ID})
+				continue
+			}
+			resp.Counters = append(resp.Counters, toCounter(&numbers[i]))
+		}
+	}
+	if more {
+		last := resp.Counters[len(resp.Counters)-1].Name
+		resp.NextPageToken = encodePageToken(pageToken{Prefix: prefix, After: last, Revision: req.GetReadRevision()})
+	}
+	return resp, nil
+}
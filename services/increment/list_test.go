@@ -0,0 +1,153 @@
+package increment
+
+import (
+	"context"
+	"testing"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPageToken(t *testing.T) {
+	raw := encodePageToken(pageToken{Prefix: "a/", After: "a/7"})
+
+	token, err := decodePageToken(raw, "a/", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, pageToken{Prefix: "a/", After: "a/7"}, token)
+
+	pinned := encodePageToken(pageToken{Prefix: "a/", After: "a/7", Revision: 42})
+	token, err = decodePageToken(pinned, "a/", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), token.Revision)
+
+	tests := []struct {
+		name     string
+		raw      string
+		prefix   string
+		revision uint64
+	}{
+		{name: "NotBase64", raw: "%%%", prefix: "a/"},
+		{name: "NotJSON", raw: "bm90LWpzb24", prefix: "a/"},
+		{name: "InvalidName", raw: encodePageToken(pageToken{Prefix: "a/", After: " bad"}), prefix: "a/"},
+		{name: "OtherPrefix", raw: raw, prefix: "b/"},
+		{name: "OtherRevision", raw: pinned, prefix: "a/", revision: 41},
+		{name: "PinnedTokenForLatest", raw: pinned, prefix: "a/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodePageToken(tt.raw, tt.prefix, tt.revision)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestListCounters(t *testing.T) {
+
+	t.Run("pages through counters", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ListAt", "jobs/", "", 3, uint64(0)).Return([]interfaces.Number{{ID: "jobs/a", Number: 1}, {ID: "jobs/b", Number: 2}, {ID: "jobs/c", Number: 3}}, uint64(10), nil)
+		mockRepo.On("ListAt", "jobs/", "jobs/b", 3, uint64(0)).Return([]interfaces.Number{{ID: "jobs/c", Number: 3}}, uint64(11), nil)
+
+		first, err := service.ListCounters(context.Background(), &api_v1.ListCountersRequest{Prefix: "jobs/", PageSize: 2})
+		require.NoError(t, err)
+		assert.Len(t, first.Counters, 2)
+		assert.Equal(t, "jobs/b", first.Counters[1].Name)
+		assert.NotEmpty(t, first.NextPageToken)
+		assert.Equal(t, uint64(10), first.ReadRevision)
+
+		second, err := service.ListCounters(context.Background(), &api_v1.ListCountersRequest{Prefix: "jobs/", PageSize: 2, PageToken: first.NextPageToken})
+		require.NoError(t, err)
+		assert.Len(t, second.Counters, 1)
+		assert.Equal(t, uint64(3), second.Counters[0].Value)
+		assert.Empty(t, second.NextPageToken)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("names only uses key iteration", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ListIDs", "", "", defaultPageSize+1).Return([]string{"a", "b"}, nil)
+
+		resp, err := service.ListCounters(context.Background(), &api_v1.ListCountersRequest{NamesOnly: true})
+		require.NoError(t, err)
+		assert.Len(t, resp.Counters, 2)
+		assert.Equal(t, "b", resp.Counters[1].Name)
+		assert.Empty(t, resp.NextPageToken)
+		mockRepo.AssertNotCalled(t, "ListAt")
+		assert.Zero(t, resp.ReadRevision)
+	})
+
+	t.Run("pages through counters at a read revision", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ListAt", "", "", 2, uint64(7)).Return([]interfaces.Number{{ID: "a", Number: 1}, {ID: "b", Number: 2}}, uint64(7), nil)
+		mockRepo.On("ListAt", "", "a", 2, uint64(7)).Return([]interfaces.Number{{ID: "b", Number: 2}}, uint64(7), nil)
+
+		first, err := service.ListCounters(context.Background(), &api_v1.ListCountersRequest{PageSize: 1, ReadRevision: 7})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), first.ReadRevision)
+		require.NotEmpty(t, first.NextPageToken)
+
+		_, err = service.ListCounters(context.Background(), &api_v1.ListCountersRequest{PageSize: 1, PageToken: first.NextPageToken})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		second, err := service.ListCounters(context.Background(), &api_v1.ListCountersRequest{PageSize: 1, ReadRevision: 7, PageToken: first.NextPageToken})
+		require.NoError(t, err)
+		assert.Equal(t, "b", second.Counters[0].Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("names only at a read revision", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ListAt", "", "", defaultPageSize+1, uint64(7)).Return([]interfaces.Number{{ID: "a", Number: 1}}, uint64(7), nil)
+
+		resp, err := service.ListCounters(context.Background(), &api_v1.ListCountersRequest{NamesOnly: true, ReadRevision: 7})
+		require.NoError(t, err)
+		assert.Equal(t, []*api_v1.Counter{{Name: "a"}}, resp.Counters)
+		mockRepo.AssertNotCalled(t, "ListIDs")
+	})
+
+	t.Run("discarded read revision is out of range", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ListAt", "", "", defaultPageSize+1, uint64(3)).Return(nil, uint64(0), interfaces.ErrOutOfRange)
+
+		_, err := service.ListCounters(context.Background(), &api_v1.ListCountersRequest{ReadRevision: 3})
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("page size is capped", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("ListAt", "", "", maxPageSize+1, uint64(0)).Return([]interfaces.Number{}, uint64(1), nil)
+
+		resp, err := service.ListCounters(context.Background(), &api_v1.ListCountersRequest{PageSize: 5000})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Counters)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		service := NewIncrementService(new(MockNumberRepository), "default")
+		token := encodePageToken(pageToken{Prefix: "jobs/", After: "jobs/b"})
+
+		requests := []*api_v1.ListCountersRequest{
+			{PageSize: -1},
+			{Prefix: "bad prefix"},
+			{PageToken: "not a token"},
+			{Prefix: "other/", PageToken: token},
+		}
+		for _, req := range requests {
+			resp, err := service.ListCounters(context.Background(), req)
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
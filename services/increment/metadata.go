@@ -0,0 +1,132 @@
+package increment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"unicode/utf8"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxLabels is the most labels a counter can carry
+	maxLabels = 64
+	// maxLabelValueLength is the longest label value accepted, in characters
+	maxLabelValueLength = 256
+	// maxDescriptionLength is the longest description accepted, in characters
+	maxDescriptionLength = 1024
+	// maxOwnerLength is the longest owner accepted, in characters
+	maxOwnerLength = 256
+)
+
+// labelKeyPattern is the grammar label keys must follow
+var labelKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_.-]{0,62}$`)
+
+// counterMetadata holds validated counter metadata
+type counterMetadata struct {
+	labels      map[string]string
+	description string
+	owner       string
+}
+
+// fromMetadata validates requested metadata
+// - md: *api_v1.CounterMetadata requested metadata, nil for none
+// Returns counterMetadata the validated metadata, or InvalidArgument if it is not valid
+func fromMetadata(md *api_v1.CounterMetadata) (counterMetadata, error) {
+	if len(md.GetLabels()) > maxLabels {
+		return counterMetadata{}, status.Errorf(codes.InvalidArgument, "at most %d labels are allowed", maxLabels)
+	}
+	var labels map[string]string
+	for key, value := range md.GetLabels() {
+		if !labelKeyPattern.MatchString(key) {
+			return counterMetadata{}, status.Errorf(codes.InvalidArgument, "invalid label key %q", key)
+		}
+		if utf8.RuneCountInString(value) > maxLabelValueLength {
+			return counterMetadata{}, status.Errorf(codes.InvalidArgument, "label %q value is longer than %d characters", key, maxLabelValueLength)
+		}
+		if labels == nil {
+			labels = make(map[string]string, len(md.GetLabels()))
+		}
+		labels[key] = value
+	}
+	if utf8.RuneCountInString(md.GetDescription()) > maxDescriptionLength {
+		return counterMetadata{}, status.Errorf(codes.InvalidArgument, "description is longer than %d characters", maxDescriptionLength)
+	}
+	if utf8.RuneCountInString(md.GetOwner()) > maxOwnerLength {
+		return counterMetadata{}, status.Errorf(codes.InvalidArgument, "owner is longer than %d characters", maxOwnerLength)
+	}
+	return counterMetadata{labels: labels, description: md.GetDescription(), owner: md.GetOwner()}, nil
+}
+
+// metadataPaths resolves which metadata fields an update mask writes
+// - paths: []string update mask paths, empty writes every field
+// Returns map[string]bool the fields to write, nil for every field, or InvalidArgument for an unknown path
+func metadataPaths(paths []string) (map[string]bool, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	fields := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		switch path {
+		case "labels", "description", "owner":
+			fields[path] = true
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown update mask path %q", path)
+		}
+	}
+	return fields, nil
+}
+
+// apply writes the selected metadata fields onto a number
+// - number: *interfaces.Number number to update
+// - fields: map[string]bool fields to write, nil writes every field
+func (md counterMetadata) apply(number *interfaces.Number, fields map[string]bool) {
+	if fields == nil || fields["labels"] {
+		number.Labels = md.labels
+	}
+	if fields == nil || fields["description"] {
+		number.Description = md.description
+	}
+	if fields == nil || fields["owner"] {
+		number.Owner = md.owner
+	}
+}
+
+// UpdateCounterMetadata changes the labels, description or owner of an existing counter
+// - ctx: context.Context context
+// - req: *api_v1.UpdateCounterMetadataRequest request
+// Returns *api_v1.Counter the updated counter, NotFound if it does not exist, or
+// InvalidArgument if the metadata or update mask are not valid
+func (s *ServiceImpl) UpdateCounterMetadata(ctx context.Context, req *api_v1.UpdateCounterMetadataRequest) (*api_v1.Counter, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	md, err := fromMetadata(req.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+	fields, err := metadataPaths(req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, err
+	}
+	number, err := s.repo.Update(name, attributed(ctx, func(number *interfaces.Number) error {
+		if number.Version == 0 {
+			return fmt.Errorf("%w: %s", interfaces.ErrNotFound, name)
+		}
+		md.apply(number, fields)
+		return nil
+	}))
+	if err != nil {
+		slog.Warn("Error updating counter metadata", "bucket", name, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	slog.Info("Updated counter metadata", "bucket", name, "caller", callerIdentity(ctx), "version", number.Version)
+	return toCounter(number), nil
+}
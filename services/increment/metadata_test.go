@@ -0,0 +1,178 @@
+package increment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestFromMetadata(t *testing.T) {
+	tooMany := make(map[string]string, maxLabels+1)
+	for i := 0; i <= maxLabels; i++ {
+		tooMany[fmt.Sprintf("label%d", i)] = "x"
+	}
+
+	tests := []struct {
+		name     string
+		metadata *api_v1.CounterMetadata
+		wantErr  bool
+	}{
+		{name: "Nil", metadata: nil},
+		{name: "Valid", metadata: &api_v1.CounterMetadata{Labels: map[string]string{"team": "payments", "env.tier-1_a": ""}, Description: "orders", Owner: "alice"}},
+		{name: "UppercaseKey", metadata: &api_v1.CounterMetadata{Labels: map[string]string{"Team": "payments"}}, wantErr: true},
+		{name: "DigitFirstKey", metadata: &api_v1.CounterMetadata{Labels: map[string]string{"1team": "payments"}}, wantErr: true},
+		{name: "LongKey", metadata: &api_v1.CounterMetadata{Labels: map[string]string{"a" + strings.Repeat("b", 63): "x"}}, wantErr: true},
+		{name: "LongValue", metadata: &api_v1.CounterMetadata{Labels: map[string]string{"team": strings.Repeat("x", maxLabelValueLength+1)}}, wantErr: true},
+		{name: "TooManyLabels", metadata: &api_v1.CounterMetadata{Labels: tooMany}, wantErr: true},
+		{name: "LongDescription", metadata: &api_v1.CounterMetadata{Description: strings.Repeat("x", maxDescriptionLength+1)}, wantErr: true},
+		{name: "LongOwner", metadata: &api_v1.CounterMetadata{Owner: strings.Repeat("x", maxOwnerLength+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md, err := fromMetadata(tt.metadata)
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.metadata.GetDescription(), md.description)
+				assert.Equal(t, tt.metadata.GetOwner(), md.owner)
+				assert.Equal(t, len(tt.metadata.GetLabels()), len(md.labels))
+			}
+		})
+	}
+}
+
+func TestUpdateCounterMetadata(t *testing.T) {
+	stored := func() *interfaces.Number {
+		return &interfaces.Number{
+			ID:          "orders",
+			Number:      3,
+			Version:     2,
+			Labels:      map[string]string{"team": "payments"},
+			Description: "orders placed",
+			Owner:       "alice",
+			CreatedAt:   time.Unix(1700000000, 0),
+		}
+	}
+	metadata := &api_v1.CounterMetadata{Labels: map[string]string{"team": "checkout"}, Description: "checkouts", Owner: "bob"}
+
+	t.Run("empty mask replaces every field", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "orders").Return(stored(), nil)
+
+		counter, err := service.UpdateCounterMetadata(context.Background(), &api_v1.UpdateCounterMetadataRequest{Name: "orders", Metadata: metadata})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"team": "checkout"}, counter.Labels)
+		assert.Equal(t, "checkouts", counter.Description)
+		assert.Equal(t, "bob", counter.Owner)
+		assert.Equal(t, int64(1700000000), counter.CreateTime.GetSeconds())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("mask limits the written fields", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "orders").Return(stored(), nil)
+
+		counter, err := service.UpdateCounterMetadata(context.Background(), &api_v1.UpdateCounterMetadataRequest{
+			Name:       "orders",
+			Metadata:   metadata,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"owner"}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"team": "payments"}, counter.Labels)
+		assert.Equal(t, "orders placed", counter.Description)
+		assert.Equal(t, "bob", counter.Owner)
+	})
+
+	t.Run("masked field without a value is cleared", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "orders").Return(stored(), nil)
+
+		counter, err := service.UpdateCounterMetadata(context.Background(), &api_v1.UpdateCounterMetadataRequest{
+			Name:       "orders",
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"labels"}},
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, counter.Labels)
+		assert.Equal(t, "alice", counter.Owner)
+	})
+
+	t.Run("unknown mask path is invalid", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		_, err := service.UpdateCounterMetadata(context.Background(), &api_v1.UpdateCounterMetadataRequest{
+			Name:       "orders",
+			Metadata:   metadata,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"value"}},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockRepo.AssertNotCalled(t, "Update", "orders")
+	})
+
+	t.Run("invalid metadata is rejected", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		_, err := service.UpdateCounterMetadata(context.Background(), &api_v1.UpdateCounterMetadataRequest{
+			Name:     "orders",
+			Metadata: &api_v1.CounterMetadata{Labels: map[string]string{"Bad Key": "x"}},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("counter saved before metadata was tracked is updated", func(t *testing.T) {
+		repo := legacyRepository(t, map[string]uint64{"orders": 500})
+		service := NewIncrementService(repo, "default")
+
+		counter, err := service.UpdateCounterMetadata(context.Background(), &api_v1.UpdateCounterMetadataRequest{
+			Name:     "orders",
+			Metadata: &api_v1.CounterMetadata{Owner: "alice"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "alice", counter.Owner)
+		assert.Equal(t, uint64(500), counter.Value)
+		assert.Nil(t, counter.CreateTime, "the creation time of the counter is not known")
+	})
+
+	t.Run("missing counter is not found", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "orders").Return(&interfaces.Number{ID: "orders"}, nil)
+
+		_, err := service.UpdateCounterMetadata(context.Background(), &api_v1.UpdateCounterMetadataRequest{Name: "orders", Metadata: metadata})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("repository error is internal", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "orders").Return(nil, errors.New("disk full"))
+
+		_, err := service.UpdateCounterMetadata(context.Background(), &api_v1.UpdateCounterMetadataRequest{Name: "orders", Metadata: metadata})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
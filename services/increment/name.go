@@ -0,0 +1,20 @@
+package increment
+
+import (
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// resolveName returns the counter name to operate on
+// - name: string requested counter name, empty selects the default bucket
+// Returns the resolved name or an InvalidArgument status if the name is not valid
+func (s *ServiceImpl) resolveName(name string) (string, error) {
+	if name == "" {
+		return s.bucket, nil
+	}
+	if !rpc.ValidName(name) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid counter name %q", name)
+	}
+	return name, nil
+}
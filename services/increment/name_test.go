@@ -0,0 +1,42 @@
+package increment
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResolveName(t *testing.T) {
+	service := NewIncrementService(new(MockNumberRepository), "default-bucket")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "EmptyUsesDefault", input: "", expected: "default-bucket"},
+		{name: "Simple", input: "requests", expected: "requests"},
+		{name: "WithSeparators", input: "tenant-1/api.calls:v2_total", expected: "tenant-1/api.calls:v2_total"},
+		{name: "LeadingSeparator", input: "/requests", wantErr: true},
+		{name: "Whitespace", input: "my counter", wantErr: true},
+		{name: "ReservedPrefix", input: "!internal", wantErr: true},
+		{name: "TooLong", input: strings.Repeat("a", 129), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := service.resolveName(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, name)
+			}
+		})
+	}
+}
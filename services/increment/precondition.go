@@ -0,0 +1,28 @@
+package increment
+
+import (
+	"fmt"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+)
+
+// checkPreconditions verifies the optional compare-and-swap expectations against a stored number
+// - number: *interfaces.Number stored number, a version of 0 means it does not exist yet
+// - expectedValue: *uint64 value the number must have, nil to skip the check, only uint64 numbers support it
+// - expectedVersion: *uint64 version the number must have, nil to skip the check
+// Returns ErrVersionMismatch or ErrPreconditionFailed when an expectation does not hold, or
+// ErrTypeMismatch when a value is expected from a number of another type
+func checkPreconditions(number *interfaces.Number, expectedValue, expectedVersion *uint64) error {
+	if expectedVersion != nil && *expectedVersion != number.Version {
+		return fmt.Errorf("%w: %s is at version %d, expected %d", interfaces.ErrVersionMismatch, number.ID, number.Version, *expectedVersion)
+	}
+	if expectedValue != nil {
+		if err := checkType(number, interfaces.NumberTypeUint64); err != nil {
+			return err
+		}
+	}
+	if expectedValue != nil && *expectedValue != number.Number {
+		return fmt.Errorf("%w: %s has value %d, expected %d", interfaces.ErrPreconditionFailed, number.ID, number.Number, *expectedValue)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package increment
+
+import (
+	"testing"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCheckPreconditions(t *testing.T) {
+	stored := &interfaces.Number{ID: "stock", Number: 10, Version: 4}
+
+	tests := []struct {
+		name            string
+		expectedValue   *uint64
+		expectedVersion *uint64
+		wantErr         error
+	}{
+		{name: "NoExpectations"},
+		{name: "MatchingValue", expectedValue: proto.Uint64(10)},
+		{name: "MatchingVersion", expectedVersion: proto.Uint64(4)},
+		{name: "MatchingBoth", expectedValue: proto.Uint64(10), expectedVersion: proto.Uint64(4)},
+		{name: "ValueMismatch", expectedValue: proto.Uint64(11), wantErr: interfaces.ErrPreconditionFailed},
+		{name: "VersionMismatch", expectedVersion: proto.Uint64(3), wantErr: interfaces.ErrVersionMismatch},
+		{name: "VersionCheckedFirst", expectedValue: proto.Uint64(11), expectedVersion: proto.Uint64(3), wantErr: interfaces.ErrVersionMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPreconditions(stored, tt.expectedValue, tt.expectedVersion)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckPreconditionsTyped(t *testing.T) {
+	stored := &interfaces.Number{ID: "balance", Number: 0, Version: 2, Type: interfaces.NumberTypeInt64}
+
+	assert.NoError(t, checkPreconditions(stored, nil, proto.Uint64(2)))
+	assert.ErrorIs(t, checkPreconditions(stored, proto.Uint64(0), nil), interfaces.ErrTypeMismatch)
+}
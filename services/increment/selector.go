@@ -0,0 +1,114 @@
+package increment
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// selectorOperator is how a label requirement compares a label
+type selectorOperator int
+
+const (
+	// selectorEquals requires the label to have a value
+	selectorEquals selectorOperator = iota
+	// selectorNotEquals requires the label to be absent or have another value
+	selectorNotEquals
+	// selectorExists requires the label to be present
+	selectorExists
+	// selectorNotExists requires the label to be absent
+	selectorNotExists
+)
+
+// labelRequirement is a single term of a label selector
+type labelRequirement struct {
+	key      string
+	value    string
+	operator selectorOperator
+}
+
+// matches reports whether a set of labels satisfies the requirement
+// - labels: map[string]string labels of a counter
+// Returns true when the requirement holds
+func (r labelRequirement) matches(labels map[string]string) bool {
+	value, ok := labels[r.key]
+	switch r.operator {
+	case selectorEquals:
+		return ok && value == r.value
+	case selectorNotEquals:
+		return !ok || value != r.value
+	case selectorExists:
+		return ok
+	default:
+		return !ok
+	}
+}
+
+// labelSelector is a parsed label selector, every requirement must hold
+type labelSelector []labelRequirement
+
+// parseSelector parses a comma separated label selector such as team=payments,env!=dev
+// - raw: string selector, each term one of key=value, key==value, key!=value, key or !key
+// Returns labelSelector the parsed requirements, or InvalidArgument if a term is malformed
+func parseSelector(raw string) (labelSelector, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, status.Error(codes.InvalidArgument, "selector is required")
+	}
+	selector := labelSelector{}
+	for _, term := range strings.Split(raw, ",") {
+		term = strings.TrimSpace(term)
+		var requirement labelRequirement
+		switch {
+		case strings.Contains(term, "!="):
+			key, value, _ := strings.Cut(term, "!=")
+			requirement = labelRequirement{key: key, value: value, operator: selectorNotEquals}
+		case strings.Contains(term, "=="):
+			key, value, _ := strings.Cut(term, "==")
+			requirement = labelRequirement{key: key, value: value, operator: selectorEquals}
+		case strings.Contains(term, "="):
+			key, value, _ := strings.Cut(term, "=")
+			requirement = labelRequirement{key: key, value: value, operator: selectorEquals}
+		case strings.HasPrefix(term, "!"):
+			requirement = labelRequirement{key: term[1:], operator: selectorNotExists}
+		default:
+			requirement = labelRequirement{key: term, operator: selectorExists}
+		}
+		requirement.key = strings.TrimSpace(requirement.key)
+		requirement.value = strings.TrimSpace(requirement.value)
+		if !labelKeyPattern.MatchString(requirement.key) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid selector term %q", term)
+		}
+		selector = append(selector, requirement)
+	}
+	return selector, nil
+}
+
+// matches reports whether a set of labels satisfies every requirement
+// - labels: map[string]string labels of a counter
+// Returns true when every requirement holds
+func (s labelSelector) matches(labels map[string]string) bool {
+	for _, requirement := range s {
+		if !requirement.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexed picks the requirement used to look counters up in the label index, equality
+// is preferred as it narrows the lookup to a single value
+// Returns the requirement, or false when only negative requirements were given
+func (s labelSelector) indexed() (labelRequirement, bool) {
+	for _, requirement := range s {
+		if requirement.operator == selectorEquals {
+			return requirement, true
+		}
+	}
+	for _, requirement := range s {
+		if requirement.operator == selectorExists {
+			return requirement, true
+		}
+	}
+	return labelRequirement{}, false
+}
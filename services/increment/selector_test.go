@@ -0,0 +1,76 @@
+package increment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected labelSelector
+		wantErr  bool
+	}{
+		{name: "Equals", raw: "team=payments", expected: labelSelector{{key: "team", value: "payments", operator: selectorEquals}}},
+		{name: "DoubleEquals", raw: "team==payments", expected: labelSelector{{key: "team", value: "payments", operator: selectorEquals}}},
+		{
+			name: "Several",
+			raw:  "team=payments, env!=dev,tier,!legacy",
+			expected: labelSelector{
+				{key: "team", value: "payments", operator: selectorEquals},
+				{key: "env", value: "dev", operator: selectorNotEquals},
+				{key: "tier", operator: selectorExists},
+				{key: "legacy", operator: selectorNotExists},
+			},
+		},
+		{name: "EmptyValue", raw: "team=", expected: labelSelector{{key: "team", operator: selectorEquals}}},
+		{name: "Empty", raw: " ", wantErr: true},
+		{name: "EmptyTerm", raw: "team=a,,env=b", wantErr: true},
+		{name: "InvalidKey", raw: "Team=payments", wantErr: true},
+		{name: "MissingKey", raw: "=payments", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := parseSelector(tt.raw)
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, selector)
+		})
+	}
+}
+
+func TestLabelSelector(t *testing.T) {
+	selector, err := parseSelector("team=payments,env!=dev,tier,!legacy")
+	require.NoError(t, err)
+
+	assert.True(t, selector.matches(map[string]string{"team": "payments", "tier": "1"}))
+	assert.True(t, selector.matches(map[string]string{"team": "payments", "tier": "1", "env": "prod"}))
+	assert.False(t, selector.matches(map[string]string{"team": "payments", "tier": "1", "env": "dev"}))
+	assert.False(t, selector.matches(map[string]string{"team": "payments"}))
+	assert.False(t, selector.matches(map[string]string{"team": "payments", "tier": "1", "legacy": ""}))
+	assert.False(t, selector.matches(nil))
+
+	lookup, ok := selector.indexed()
+	assert.True(t, ok)
+	assert.Equal(t, "team", lookup.key)
+
+	selector, err = parseSelector("env!=dev,tier")
+	require.NoError(t, err)
+	lookup, ok = selector.indexed()
+	assert.True(t, ok)
+	assert.Equal(t, labelRequirement{key: "tier", operator: selectorExists}, lookup)
+
+	selector, err = parseSelector("env!=dev,!legacy")
+	require.NoError(t, err)
+	_, ok = selector.indexed()
+	assert.False(t, ok)
+}
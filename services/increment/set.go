@@ -0,0 +1,93 @@
+package increment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Set writes an absolute value to the named counter
+// - ctx: context.Context context
+// - req: *api_v1.SetRequest request
+// Returns *api_v1.Counter the written counter, Aborted or FailedPrecondition if an expectation
+// does not match, or FailedPrecondition if the value does not fit the type of the counter
+func (s *ServiceImpl) Set(ctx context.Context, req *api_v1.SetRequest) (*api_v1.Counter, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	expiry, err := fromExpiry(req.GetExpiry())
+	if err != nil {
+		return nil, err
+	}
+	value := interfaces.Number{Number: req.GetValue()}
+	if req.TypedValue != nil {
+		if req.GetValue() != 0 {
+			return nil, status.Error(codes.InvalidArgument, "value and typed_value are mutually exclusive")
+		}
+		value, err = fromTypedValue(req.GetTypedValue())
+		if err != nil {
+			return nil, err
+		}
+	}
+	number, err := s.repo.Update(name, attributed(ctx, func(number *interfaces.Number) error {
+		if err := checkPreconditions(number, req.ExpectedValue, req.ExpectedVersion); err != nil {
+			return err
+		}
+		setExpiry(number, expiry)
+		return applyTypedValue(number, value)
+	}))
+	if err != nil {
+		slog.Warn("Error setting number", "bucket", name, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	slog.Info("Set number", "bucket", name, "number", number.Number, "version", number.Version)
+	return toCounter(number), nil
+}
+
+// Reset sets the named counter back to zero, or to the lower bound of a uint64 counter
+// - ctx: context.Context context
+// - req: *api_v1.ResetRequest request
+// Returns *api_v1.Counter the reset counter, NotFound if it does not exist,
+// Aborted or FailedPrecondition if an expectation does not match
+func (s *ServiceImpl) Reset(ctx context.Context, req *api_v1.ResetRequest) (*api_v1.Counter, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	number, err := s.repo.Update(name, attributed(ctx, func(number *interfaces.Number) error {
+		if number.Version == 0 {
+			return fmt.Errorf("%w: %s", interfaces.ErrNotFound, name)
+		}
+		if err := checkPreconditions(number, req.ExpectedValue, req.ExpectedVersion); err != nil {
+			return err
+		}
+		if number.Type == interfaces.NumberTypeDistinct {
+			// a distinct counter starts over with an empty sketch of the same precision
+			sk, err := loadSketch(number)
+			if err != nil {
+				return err
+			}
+			storeSketch(number, newSketch(sk.precision))
+			return nil
+		}
+		var floor uint64
+		if number.Bounds != nil {
+			floor = number.Bounds.Min
+		}
+		// zero is encoded as zero bits for every type and an empty big integer
+		return applyTypedValue(number, interfaces.Number{Number: floor, Type: number.Type})
+	}))
+	if err != nil {
+		slog.Warn("Error resetting number", "bucket", name, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	slog.Info("Reset number", "bucket", name, "version", number.Version)
+	return toCounter(number), nil
+}
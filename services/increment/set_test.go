@@ -0,0 +1,240 @@
+package increment
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestSet(t *testing.T) {
+
+	t.Run("sets value", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "stock").Return(&interfaces.Number{ID: "stock", Number: 3, Version: 2}, nil)
+
+		counter, err := service.Set(context.Background(), &api_v1.SetRequest{Name: "stock", Value: 50})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(50), counter.Value)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("expiry replaces and restarts the deadline", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		stored := &interfaces.Number{ID: "stock", Number: 3, Version: 2, Expiry: &interfaces.Expiry{TTL: time.Minute}, ExpiresAt: time.Now().Add(time.Second)}
+		mockRepo.On("Update", "stock").Return(stored, nil)
+
+		_, err := service.Set(context.Background(), &api_v1.SetRequest{
+			Name:   "stock",
+			Value:  50,
+			Expiry: &api_v1.Expiry{Ttl: durationpb.New(time.Hour), Mode: api_v1.ExpiryMode_EXPIRY_MODE_SLIDING},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &interfaces.Expiry{TTL: time.Hour, Sliding: true}, stored.Expiry)
+		assert.True(t, stored.ExpiresAt.IsZero())
+	})
+
+	t.Run("invalid expiry is rejected", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		counter, err := service.Set(context.Background(), &api_v1.SetRequest{Name: "stock", Value: 50, Expiry: &api_v1.Expiry{}})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockRepo.AssertNotCalled(t, "Update", "stock")
+	})
+
+	t.Run("expected version matches", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "stock").Return(&interfaces.Number{ID: "stock", Number: 3, Version: 2}, nil)
+
+		counter, err := service.Set(context.Background(), &api_v1.SetRequest{Name: "stock", Value: 50, ExpectedVersion: proto.Uint64(2)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(50), counter.Value)
+	})
+
+	t.Run("expected version mismatch is aborted", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "stock").Return(&interfaces.Number{ID: "stock", Number: 3, Version: 2}, nil)
+
+		counter, err := service.Set(context.Background(), &api_v1.SetRequest{Name: "stock", Value: 50, ExpectedVersion: proto.Uint64(1)})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("expected value mismatch is failed precondition", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "stock").Return(&interfaces.Number{ID: "stock", Number: 3, Version: 2}, nil)
+
+		counter, err := service.Set(context.Background(), &api_v1.SetRequest{Name: "stock", Value: 50, ExpectedValue: proto.Uint64(4)})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("expected version zero creates", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "fresh").Return(&interfaces.Number{ID: "fresh"}, nil)
+
+		counter, err := service.Set(context.Background(), &api_v1.SetRequest{Name: "fresh", Value: 7, ExpectedVersion: proto.Uint64(0)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7), counter.Value)
+	})
+
+	t.Run("expected version zero fails for a counter saved before versions were tracked", func(t *testing.T) {
+		repo := legacyRepository(t, map[string]uint64{"orders": 500})
+		service := NewIncrementService(repo, "default")
+
+		counter, err := service.Set(context.Background(), &api_v1.SetRequest{Name: "orders", Value: 7, ExpectedVersion: proto.Uint64(0)})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		stored, err := repo.FindByID("orders")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(500), stored.Number)
+	})
+}
+
+func TestReset(t *testing.T) {
+
+	t.Run("resets value", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "default").Return(&interfaces.Number{ID: "default", Number: 12, Version: 5}, nil)
+
+		counter, err := service.Reset(context.Background(), &api_v1.ResetRequest{ExpectedValue: proto.Uint64(12)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), counter.Value)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("resets bounded counter to min", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "default").Return(&interfaces.Number{ID: "default", Number: 12, Version: 5, Bounds: &interfaces.Bounds{Min: 3, Max: 20}}, nil)
+
+		counter, err := service.Reset(context.Background(), &api_v1.ResetRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(3), counter.Value)
+	})
+
+	t.Run("resets distinct counter to an empty sketch", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		number := distinctNumber("visitors", 10, 0, 100)
+		mockRepo.On("Update", "visitors").Return(&number, nil)
+
+		counter, err := service.Reset(context.Background(), &api_v1.ResetRequest{Name: "visitors"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), counter.TypedValue.GetUint64Value())
+		assert.Equal(t, newSketch(10).encode(), number.Sketch)
+	})
+
+	t.Run("resets a counter saved before versions were tracked", func(t *testing.T) {
+		service := NewIncrementService(legacyRepository(t, map[string]uint64{"orders": 500}), "default")
+
+		counter, err := service.Reset(context.Background(), &api_v1.ResetRequest{Name: "orders", ExpectedVersion: proto.Uint64(1)})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), counter.Value)
+		assert.Equal(t, uint64(2), counter.Version)
+	})
+
+	t.Run("missing counter is not found", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "missing").Return(&interfaces.Number{ID: "missing"}, nil)
+
+		counter, err := service.Reset(context.Background(), &api_v1.ResetRequest{Name: "missing"})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("expected version mismatch is aborted", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "default").Return(&interfaces.Number{ID: "default", Number: 12, Version: 5}, nil)
+
+		counter, err := service.Reset(context.Background(), &api_v1.ResetRequest{ExpectedVersion: proto.Uint64(4)})
+
+		assert.Nil(t, counter)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+}
+
+func TestSetTyped(t *testing.T) {
+	t.Run("sets a value of the counter type", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "balance").Return(&interfaces.Number{ID: "balance", Version: 1, Type: interfaces.NumberTypeInt64}, nil)
+
+		counter, err := service.Set(context.Background(), &api_v1.SetRequest{
+			Name:       "balance",
+			TypedValue: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Int64Value{Int64Value: -40}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, api_v1.CounterType_COUNTER_TYPE_INT64, counter.Type)
+		assert.Equal(t, int64(-40), counter.TypedValue.GetInt64Value())
+		assert.Zero(t, counter.Value)
+	})
+
+	t.Run("value of another type is a failed precondition", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "load").Return(&interfaces.Number{ID: "load", Version: 1, Type: interfaces.NumberTypeFloat64}, nil)
+
+		_, err := service.Set(context.Background(), &api_v1.SetRequest{Name: "load", Value: 3})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("both values are invalid", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+
+		_, err := service.Set(context.Background(), &api_v1.SetRequest{
+			Name:       "load",
+			Value:      3,
+			TypedValue: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Uint64Value{Uint64Value: 3}},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("reset zeroes a gauge", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Update", "load").Return(&interfaces.Number{ID: "load", Version: 3, Number: math.Float64bits(2.5), Type: interfaces.NumberTypeFloat64}, nil)
+
+		counter, err := service.Reset(context.Background(), &api_v1.ResetRequest{Name: "load"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, counter.TypedValue.GetFloat64Value())
+	})
+}
@@ -0,0 +1,106 @@
+package increment
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sketchOf builds a sketch holding the items item-from to item-to, to excluded
+func sketchOf(precision uint8, from int, to int) *sketch {
+	sk := newSketch(precision)
+	for i := from; i < to; i++ {
+		sk.add(fmt.Sprintf("item-%d", i))
+	}
+	return sk
+}
+
+// assertEstimate checks an estimate is within a relative error of the actual cardinality
+func assertEstimate(t *testing.T, actual int, estimate uint64, tolerance float64) {
+	t.Helper()
+	assert.InDelta(t, float64(actual), float64(estimate), float64(actual)*tolerance, "estimate %d of %d", estimate, actual)
+}
+
+func TestSketchEstimate(t *testing.T) {
+	assert.Equal(t, uint64(0), newSketch(defaultDistinctPrecision).estimate())
+
+	for _, cardinality := range []int{1, 10, 1000, 100000} {
+		t.Run(fmt.Sprint(cardinality), func(t *testing.T) {
+			sk := sketchOf(defaultDistinctPrecision, 0, cardinality)
+			assertEstimate(t, cardinality, sk.estimate(), 0.03)
+		})
+	}
+}
+
+func TestSketchAddIsIdempotent(t *testing.T) {
+	sk := sketchOf(12, 0, 500)
+	before := sk.encode()
+	for i := 0; i < 500; i++ {
+		sk.add(fmt.Sprintf("item-%d", i))
+	}
+	assert.Equal(t, before, sk.encode())
+}
+
+func TestSketchMerge(t *testing.T) {
+	left := sketchOf(12, 0, 6000)
+	right := sketchOf(12, 4000, 10000)
+	before := left.encode()
+
+	merged := left.merge(right)
+
+	assert.Equal(t, sketchOf(12, 0, 10000).encode(), merged.encode(), "merging equals adding every item to one sketch")
+	assert.Equal(t, before, left.encode(), "inputs are not changed")
+}
+
+func TestSketchFold(t *testing.T) {
+	for _, precision := range []uint8{minDistinctPrecision, 10, 13} {
+		folded := sketchOf(14, 0, 20000).fold(precision)
+		assert.Equal(t, sketchOf(precision, 0, 20000).encode(), folded.encode(), "folding to %d equals adding at %d", precision, precision)
+	}
+
+	merged := sketchOf(14, 0, 3000).merge(sketchOf(10, 3000, 6000))
+	assert.Equal(t, uint8(10), merged.precision)
+	assert.Equal(t, sketchOf(10, 0, 6000).encode(), merged.encode())
+}
+
+func TestDecodeSketch(t *testing.T) {
+	sk := sketchOf(minDistinctPrecision, 0, 100)
+	decoded, err := decodeSketch(sk.encode())
+	require.NoError(t, err)
+	assert.Equal(t, sk, decoded)
+
+	tooHigh := newSketch(minDistinctPrecision).encode()
+	tooHigh[1] = 64 - minDistinctPrecision + 2
+	for name, data := range map[string][]byte{
+		"empty":           nil,
+		"low precision":   append([]byte{minDistinctPrecision - 1}, make([]byte, 8)...),
+		"high precision":  {maxDistinctPrecision + 1},
+		"short registers": newSketch(minDistinctPrecision).encode()[:10],
+		"impossible rank": tooHigh,
+	} {
+		_, err := decodeSketch(data)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLoadSketch(t *testing.T) {
+	number := &interfaces.Number{ID: "visitors"}
+	storeSketch(number, sketchOf(defaultDistinctPrecision, 0, 10))
+	assert.Equal(t, interfaces.NumberTypeDistinct, number.Type)
+	assert.Equal(t, uint64(10), number.Number)
+
+	loaded, err := loadSketch(number)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(defaultDistinctPrecision), loaded.precision)
+
+	_, err = loadSketch(&interfaces.Number{ID: "orders", Number: 3})
+	assert.ErrorIs(t, err, interfaces.ErrTypeMismatch)
+
+	_, err = loadSketch(&interfaces.Number{ID: "visitors", Type: interfaces.NumberTypeDistinct, Sketch: []byte{math.MaxUint8}})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, interfaces.ErrTypeMismatch)
+}
@@ -0,0 +1,164 @@
+package increment
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBigDigits caps the decimal digits of big integer values and deltas so a counter stays
+// small enough to store and compare cheaply
+const maxBigDigits = 1000
+
+// typeNames names the number types in error messages
+var typeNames = map[interfaces.NumberType]string{
+	interfaces.NumberTypeUint64:   "uint64",
+	interfaces.NumberTypeInt64:    "int64",
+	interfaces.NumberTypeFloat64:  "float64",
+	interfaces.NumberTypeBigInt:   "big integer",
+	interfaces.NumberTypeDistinct: "distinct",
+}
+
+// fromCounterType converts a requested counter type into its stored form
+// - counterType: api_v1.CounterType requested type, unspecified means uint64
+// Returns interfaces.NumberType stored type, or InvalidArgument for an unknown type
+func fromCounterType(counterType api_v1.CounterType) (interfaces.NumberType, error) {
+	switch counterType {
+	case api_v1.CounterType_COUNTER_TYPE_UNSPECIFIED, api_v1.CounterType_COUNTER_TYPE_UINT64:
+		return interfaces.NumberTypeUint64, nil
+	case api_v1.CounterType_COUNTER_TYPE_INT64:
+		return interfaces.NumberTypeInt64, nil
+	case api_v1.CounterType_COUNTER_TYPE_FLOAT64:
+		return interfaces.NumberTypeFloat64, nil
+	case api_v1.CounterType_COUNTER_TYPE_BIG_INT:
+		return interfaces.NumberTypeBigInt, nil
+	case api_v1.CounterType_COUNTER_TYPE_DISTINCT:
+		return interfaces.NumberTypeDistinct, nil
+	default:
+		return 0, status.Errorf(codes.InvalidArgument, "unknown counter type %v", counterType)
+	}
+}
+
+// toCounterType converts a stored number type into its API representation
+// - numberType: interfaces.NumberType stored type
+// Returns api_v1.CounterType counter type
+func toCounterType(numberType interfaces.NumberType) api_v1.CounterType {
+	switch numberType {
+	case interfaces.NumberTypeInt64:
+		return api_v1.CounterType_COUNTER_TYPE_INT64
+	case interfaces.NumberTypeFloat64:
+		return api_v1.CounterType_COUNTER_TYPE_FLOAT64
+	case interfaces.NumberTypeBigInt:
+		return api_v1.CounterType_COUNTER_TYPE_BIG_INT
+	case interfaces.NumberTypeDistinct:
+		return api_v1.CounterType_COUNTER_TYPE_DISTINCT
+	default:
+		return api_v1.CounterType_COUNTER_TYPE_UINT64
+	}
+}
+
+// toTypedValue converts the value of a number into its API representation
+// - number: *interfaces.Number number holding the value
+// Returns *api_v1.TypedValue value message, the estimate of distinct numbers is a uint64 value
+func toTypedValue(number *interfaces.Number) *api_v1.TypedValue {
+	switch number.Type {
+	case interfaces.NumberTypeInt64:
+		return &api_v1.TypedValue{Kind: &api_v1.TypedValue_Int64Value{Int64Value: int64(number.Number)}}
+	case interfaces.NumberTypeFloat64:
+		return &api_v1.TypedValue{Kind: &api_v1.TypedValue_Float64Value{Float64Value: math.Float64frombits(number.Number)}}
+	case interfaces.NumberTypeBigInt:
+		return &api_v1.TypedValue{Kind: &api_v1.TypedValue_BigValue{BigValue: bigOf(number).String()}}
+	default:
+		return &api_v1.TypedValue{Kind: &api_v1.TypedValue_Uint64Value{Uint64Value: number.Number}}
+	}
+}
+
+// fromTypedValue converts a requested value into its stored form
+// - value: *api_v1.TypedValue requested value
+// Returns a number holding only the value and its type, or InvalidArgument if no kind is set,
+// a float is not finite or a big integer is malformed or negative
+func fromTypedValue(value *api_v1.TypedValue) (interfaces.Number, error) {
+	switch kind := value.GetKind().(type) {
+	case *api_v1.TypedValue_Uint64Value:
+		return interfaces.Number{Number: kind.Uint64Value}, nil
+	case *api_v1.TypedValue_Int64Value:
+		return interfaces.Number{Number: uint64(kind.Int64Value), Type: interfaces.NumberTypeInt64}, nil
+	case *api_v1.TypedValue_Float64Value:
+		if math.IsNaN(kind.Float64Value) || math.IsInf(kind.Float64Value, 0) {
+			return interfaces.Number{}, status.Errorf(codes.InvalidArgument, "value %v is not finite", kind.Float64Value)
+		}
+		return interfaces.Number{Number: math.Float64bits(kind.Float64Value), Type: interfaces.NumberTypeFloat64}, nil
+	case *api_v1.TypedValue_BigValue:
+		parsed, err := parseBig(kind.BigValue)
+		if err != nil {
+			return interfaces.Number{}, err
+		}
+		if parsed.Sign() < 0 {
+			return interfaces.Number{}, status.Errorf(codes.InvalidArgument, "big integer value %d is negative", parsed)
+		}
+		return interfaces.Number{Big: parsed.String(), Type: interfaces.NumberTypeBigInt}, nil
+	default:
+		return interfaces.Number{}, status.Error(codes.InvalidArgument, "typed value has no kind")
+	}
+}
+
+// parseBig parses a requested big integer
+// - raw: string decimal integer, optionally signed
+// Returns *big.Int parsed integer, or InvalidArgument if it is not a decimal integer or has
+// more than maxBigDigits digits
+func parseBig(raw string) (*big.Int, error) {
+	if len(strings.TrimLeft(raw, "+-")) > maxBigDigits {
+		return nil, status.Errorf(codes.InvalidArgument, "big integer has more than %d digits", maxBigDigits)
+	}
+	parsed, ok := new(big.Int).SetString(raw, 10)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid big integer %q", raw)
+	}
+	return parsed, nil
+}
+
+// bigOf reads the value of a big integer number
+// - number: *interfaces.Number big integer number, an empty value is 0
+// Returns *big.Int value of the number
+func bigOf(number *interfaces.Number) *big.Int {
+	value, ok := new(big.Int).SetString(number.Big, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return value
+}
+
+// checkType verifies that a number holds the type an operation needs
+// - number: *interfaces.Number number to check
+// - numberType: interfaces.NumberType type the operation needs
+// Returns ErrTypeMismatch when the number holds another type
+func checkType(number *interfaces.Number, numberType interfaces.NumberType) error {
+	if number.Type != numberType {
+		return fmt.Errorf("%w: %s is a %s counter, not %s", interfaces.ErrTypeMismatch, number.ID, typeNames[number.Type], typeNames[numberType])
+	}
+	return nil
+}
+
+// compareValues orders the values of two numbers of the same type
+// - a: *interfaces.Number first number
+// - b: *interfaces.Number second number
+// Returns -1, 0 or +1 as the value of a is below, equal to or above the value of b
+func compareValues(a *interfaces.Number, b *interfaces.Number) int {
+	switch a.Type {
+	case interfaces.NumberTypeInt64:
+		return cmp.Compare(int64(a.Number), int64(b.Number))
+	case interfaces.NumberTypeFloat64:
+		return cmp.Compare(math.Float64frombits(a.Number), math.Float64frombits(b.Number))
+	case interfaces.NumberTypeBigInt:
+		return bigOf(a).Cmp(bigOf(b))
+	default:
+		return cmp.Compare(a.Number, b.Number)
+	}
+}
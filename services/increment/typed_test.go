@@ -0,0 +1,112 @@
+package increment
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTypedValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      *api_v1.TypedValue
+		numberType interfaces.NumberType
+	}{
+		{name: "Uint64", value: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Uint64Value{Uint64Value: math.MaxUint64}}, numberType: interfaces.NumberTypeUint64},
+		{name: "Int64", value: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Int64Value{Int64Value: math.MinInt64}}, numberType: interfaces.NumberTypeInt64},
+		{name: "Float64", value: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Float64Value{Float64Value: -0.1}}, numberType: interfaces.NumberTypeFloat64},
+		{name: "NegativeZero", value: &api_v1.TypedValue{Kind: &api_v1.TypedValue_Float64Value{Float64Value: math.Copysign(0, -1)}}, numberType: interfaces.NumberTypeFloat64},
+		{name: "BigInt", value: &api_v1.TypedValue{Kind: &api_v1.TypedValue_BigValue{BigValue: "340282366920938463463374607431768211456"}}, numberType: interfaces.NumberTypeBigInt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored, err := fromTypedValue(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.numberType, stored.Type)
+			assert.True(t, proto.Equal(tt.value, toTypedValue(&stored)))
+		})
+	}
+
+	for _, invalid := range []*api_v1.TypedValue{
+		nil,
+		{},
+		{Kind: &api_v1.TypedValue_Float64Value{Float64Value: math.NaN()}},
+		{Kind: &api_v1.TypedValue_Float64Value{Float64Value: math.Inf(1)}},
+		{Kind: &api_v1.TypedValue_BigValue{BigValue: "12ab"}},
+		{Kind: &api_v1.TypedValue_BigValue{BigValue: "-1"}},
+		{Kind: &api_v1.TypedValue_BigValue{BigValue: strings.Repeat("9", maxBigDigits+1)}},
+	} {
+		_, err := fromTypedValue(invalid)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	stored, err := fromTypedValue(&api_v1.TypedValue{Kind: &api_v1.TypedValue_BigValue{BigValue: "+007"}})
+	require.NoError(t, err)
+	assert.Equal(t, "7", stored.Big, "big integers are stored in canonical form")
+}
+
+func TestParseBig(t *testing.T) {
+	parsed, err := parseBig("-" + strings.Repeat("9", maxBigDigits))
+	require.NoError(t, err)
+	assert.Equal(t, -1, parsed.Sign())
+
+	for _, invalid := range []string{"", "1.5", "0x10", "--1"} {
+		_, err := parseBig(invalid)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), invalid)
+	}
+}
+
+func TestCounterTypes(t *testing.T) {
+	for counterType, numberType := range map[api_v1.CounterType]interfaces.NumberType{
+		api_v1.CounterType_COUNTER_TYPE_UNSPECIFIED: interfaces.NumberTypeUint64,
+		api_v1.CounterType_COUNTER_TYPE_UINT64:      interfaces.NumberTypeUint64,
+		api_v1.CounterType_COUNTER_TYPE_INT64:       interfaces.NumberTypeInt64,
+		api_v1.CounterType_COUNTER_TYPE_FLOAT64:     interfaces.NumberTypeFloat64,
+		api_v1.CounterType_COUNTER_TYPE_BIG_INT:     interfaces.NumberTypeBigInt,
+		api_v1.CounterType_COUNTER_TYPE_DISTINCT:    interfaces.NumberTypeDistinct,
+	} {
+		converted, err := fromCounterType(counterType)
+		require.NoError(t, err)
+		assert.Equal(t, numberType, converted)
+		if counterType != api_v1.CounterType_COUNTER_TYPE_UNSPECIFIED {
+			assert.Equal(t, counterType, toCounterType(numberType))
+		}
+	}
+
+	_, err := fromCounterType(api_v1.CounterType(42))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCompareValues(t *testing.T) {
+	typed := func(value uint64, numberType interfaces.NumberType) *interfaces.Number {
+		return &interfaces.Number{Number: value, Type: numberType}
+	}
+	big := func(value string) *interfaces.Number {
+		return &interfaces.Number{Big: value, Type: interfaces.NumberTypeBigInt}
+	}
+	assert.Equal(t, 1, compareValues(typed(math.MaxUint64, interfaces.NumberTypeUint64), typed(1, interfaces.NumberTypeUint64)))
+	assert.Equal(t, -1, compareValues(typed(1<<64-1, interfaces.NumberTypeInt64), typed(1, interfaces.NumberTypeInt64)), "-1 sorts below 1")
+	assert.Equal(t, -1, compareValues(typed(math.Float64bits(-2.5), interfaces.NumberTypeFloat64), typed(math.Float64bits(1.5), interfaces.NumberTypeFloat64)))
+	assert.Equal(t, 0, compareValues(typed(7, interfaces.NumberTypeInt64), typed(7, interfaces.NumberTypeInt64)))
+	assert.Equal(t, 1, compareValues(big("100000000000000000000"), big("99999999999999999999")), "big integers compare by value")
+	assert.Equal(t, 0, compareValues(big(""), big("0")), "an empty big integer is 0")
+}
+
+func TestCheckType(t *testing.T) {
+	number := &interfaces.Number{ID: "gauge", Type: interfaces.NumberTypeFloat64}
+	assert.NoError(t, checkType(number, interfaces.NumberTypeFloat64))
+	err := checkType(number, interfaces.NumberTypeUint64)
+	assert.ErrorIs(t, err, interfaces.ErrTypeMismatch)
+	assert.Contains(t, err.Error(), "float64")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(rpc.ToStatus(err)))
+}
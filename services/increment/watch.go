@@ -0,0 +1,165 @@
+package increment
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchBuffer holds the events a watch has not sent yet, keeping only the latest
+// event per counter so a slow client costs at most one pending event per counter
+type watchBuffer struct {
+	mu     sync.Mutex
+	events map[string]*api_v1.WatchEvent
+	synced *api_v1.WatchEvent
+	ready  bool
+	notify chan struct{}
+}
+
+// newWatchBuffer creates an empty watchBuffer
+func newWatchBuffer() *watchBuffer {
+	return &watchBuffer{
+		events: map[string]*api_v1.WatchEvent{},
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// push adds an event, replacing any pending event for the same counter
+// - event: *api_v1.WatchEvent event to send
+func (b *watchBuffer) push(event *api_v1.WatchEvent) {
+	b.mu.Lock()
+	if event.Type == api_v1.WatchEvent_TYPE_SYNCED {
+		b.synced = event
+		b.ready = true
+	} else {
+		name := event.Counter.GetName()
+		if previous, ok := b.events[name]; ok {
+			event.Coalesced = previous.Coalesced + 1
+		}
+		b.events[name] = event
+	}
+	ready := b.ready
+	b.mu.Unlock()
+	if ready {
+		select {
+		case b.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// drain removes the pending events once the initial state is complete
+// Returns the pending events in revision order
+func (b *watchBuffer) drain() []*api_v1.WatchEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.ready {
+		return nil
+	}
+	events := make([]*api_v1.WatchEvent, 0, len(b.events)+1)
+	for _, event := range b.events {
+		events = append(events, event)
+	}
+	clear(b.events)
+	if b.synced != nil {
+		events = append(events, b.synced)
+		b.synced = nil
+	}
+	// sending in revision order lets a client resume from the last revision it saw,
+	// the synced marker carries the snapshot revision so it lands between the
+	// initial state and the first live change
+	slices.SortStableFunc(events, func(a, b *api_v1.WatchEvent) int {
+		if c := cmp.Compare(a.Revision, b.Revision); c != 0 {
+			return c
+		}
+		if a.Type == api_v1.WatchEvent_TYPE_SYNCED {
+			return 1
+		}
+		if b.Type == api_v1.WatchEvent_TYPE_SYNCED {
+			return -1
+		}
+		return 0
+	})
+	return events
+}
+
+// toWatchEvent converts a repository event into its API representation
+// - event: interfaces.NumberEvent repository event
+// Returns *api_v1.WatchEvent event message
+func toWatchEvent(event interfaces.NumberEvent) *api_v1.WatchEvent {
+	switch {
+	case event.Synced:
+		return &api_v1.WatchEvent{Type: api_v1.WatchEvent_TYPE_SYNCED, Revision: event.Revision}
+	case event.Deleted:
+		return &api_v1.WatchEvent{Type: api_v1.WatchEvent_TYPE_DELETED, Counter: &api_v1.Counter{Name: event.Number.ID}, Revision: event.Revision}
+	default:
+		return &api_v1.WatchEvent{Type: api_v1.WatchEvent_TYPE_UPDATED, Counter: toCounter(&event.Number), Revision: event.Revision}
+	}
+}
+
+// Watch streams the current state of a counter or a prefix of counters followed by every change,
+// updates to the same counter are coalesced while the client is not keeping up
+// - req: *api_v1.WatchRequest request
+// - stream: grpc.ServerStreamingServer[api_v1.WatchEvent] stream to send events on
+// Returns nil when the client goes away, or an error if watching fails
+func (s *ServiceImpl) Watch(req *api_v1.WatchRequest, stream grpc.ServerStreamingServer[api_v1.WatchEvent]) error {
+	prefix := req.GetPrefix()
+	name := ""
+	if _, isPrefix := req.GetTarget().(*api_v1.WatchRequest_Prefix); isPrefix {
+		if prefix != "" && !rpc.ValidName(prefix) {
+			return status.Errorf(codes.InvalidArgument, "invalid prefix %q", prefix)
+		}
+	} else {
+		resolved, err := s.resolveName(req.GetName())
+		if err != nil {
+			return err
+		}
+		name = resolved
+		prefix = resolved
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	buffer := newWatchBuffer()
+	watched := make(chan error, 1)
+	go func() {
+		watched <- s.repo.Watch(ctx, prefix, req.GetResumeRevision(), func(event interfaces.NumberEvent) error {
+			// a name watch uses the name as prefix, skip longer names sharing it
+			if name != "" && !event.Synced && event.Number.ID != name {
+				return nil
+			}
+			buffer.push(toWatchEvent(event))
+			return nil
+		})
+	}()
+
+	slog.Info("Watch started", "prefix", prefix, "name", name, "caller", callerIdentity(ctx))
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-watched:
+			if err != nil && !errors.Is(err, context.Canceled) {
+				slog.Error("Watch failed", "prefix", prefix, "error", err)
+				return rpc.ToStatus(err)
+			}
+			return nil
+		case <-buffer.notify:
+			for _, event := range buffer.drain() {
+				if err := stream.Send(event); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package increment
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeWatchStream records the events sent on a watch stream
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	mu     sync.Mutex
+	events []*api_v1.WatchEvent
+}
+
+func (f *fakeWatchStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeWatchStream) Send(event *api_v1.WatchEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *fakeWatchStream) sent() []*api_v1.WatchEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*api_v1.WatchEvent{}, f.events...)
+}
+
+func TestWatchBuffer(t *testing.T) {
+	buffer := newWatchBuffer()
+	counter := func(name string, value uint64) *api_v1.Counter {
+		return &api_v1.Counter{Name: name, Value: value}
+	}
+
+	buffer.push(&api_v1.WatchEvent{Type: api_v1.WatchEvent_TYPE_UPDATED, Counter: counter("a", 1), Revision: 5})
+	assert.Nil(t, buffer.drain(), "nothing is sent before the initial state is complete")
+
+	buffer.push(&api_v1.WatchEvent{Type: api_v1.WatchEvent_TYPE_UPDATED, Counter: counter("b", 1), Revision: 3})
+	buffer.push(&api_v1.WatchEvent{Type: api_v1.WatchEvent_TYPE_SYNCED, Revision: 6})
+	buffer.push(&api_v1.WatchEvent{Type: api_v1.WatchEvent_TYPE_UPDATED, Counter: counter("a", 2), Revision: 7})
+	buffer.push(&api_v1.WatchEvent{Type: api_v1.WatchEvent_TYPE_UPDATED, Counter: counter("a", 3), Revision: 8})
+
+	events := buffer.drain()
+	require.Len(t, events, 3)
+	assert.Equal(t, "b", events[0].Counter.Name)
+	assert.Equal(t, api_v1.WatchEvent_TYPE_SYNCED, events[1].Type)
+	assert.Equal(t, uint64(3), events[2].Counter.Value)
+	assert.Equal(t, uint64(2), events[2].Coalesced)
+	assert.Empty(t, buffer.drain())
+}
+
+func TestWatch(t *testing.T) {
+
+	t.Run("name watch sends only that counter", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Watch", "jobs", uint64(4)).Return([]interfaces.NumberEvent{
+			{Number: interfaces.Number{ID: "jobs", Number: 2}, Revision: 5},
+			{Number: interfaces.Number{ID: "jobs-old", Number: 1}, Revision: 6},
+			{Synced: true, Revision: 7},
+			{Number: interfaces.Number{ID: "jobs-old"}, Deleted: true, Revision: 8},
+		}, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &fakeWatchStream{ctx: ctx}
+		done := make(chan error, 1)
+		go func() {
+			done <- service.Watch(&api_v1.WatchRequest{Target: &api_v1.WatchRequest_Name{Name: "jobs"}, ResumeRevision: 4}, stream)
+		}()
+
+		require.Eventually(t, func() bool { return len(stream.sent()) == 2 }, time.Second, 5*time.Millisecond)
+		cancel()
+		assert.NoError(t, <-done)
+
+		events := stream.sent()
+		require.Len(t, events, 2)
+		assert.Equal(t, api_v1.WatchEvent_TYPE_UPDATED, events[0].Type)
+		assert.Equal(t, uint64(2), events[0].Counter.Value)
+		assert.Equal(t, api_v1.WatchEvent_TYPE_SYNCED, events[1].Type)
+		assert.Equal(t, uint64(7), events[1].Revision)
+	})
+
+	t.Run("prefix watch sends deletions", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Watch", "jobs/", uint64(0)).Return([]interfaces.NumberEvent{
+			{Synced: true, Revision: 2},
+			{Number: interfaces.Number{ID: "jobs/a"}, Deleted: true, Revision: 3},
+		}, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &fakeWatchStream{ctx: ctx}
+		done := make(chan error, 1)
+		go func() {
+			done <- service.Watch(&api_v1.WatchRequest{Target: &api_v1.WatchRequest_Prefix{Prefix: "jobs/"}}, stream)
+		}()
+
+		require.Eventually(t, func() bool { return len(stream.sent()) == 2 }, time.Second, 5*time.Millisecond)
+		cancel()
+		assert.NoError(t, <-done)
+
+		events := stream.sent()
+		assert.Equal(t, api_v1.WatchEvent_TYPE_SYNCED, events[0].Type)
+		assert.Equal(t, api_v1.WatchEvent_TYPE_DELETED, events[1].Type)
+		assert.Equal(t, "jobs/a", events[1].Counter.Name)
+	})
+
+	t.Run("repository error ends the stream", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("Watch", "jobs/", uint64(0)).Return([]interfaces.NumberEvent{}, interfaces.ErrSaveFailed)
+
+		stream := &fakeWatchStream{ctx: context.Background()}
+		err := service.Watch(&api_v1.WatchRequest{Target: &api_v1.WatchRequest_Prefix{Prefix: "jobs/"}}, stream)
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("invalid prefix", func(t *testing.T) {
+		service := NewIncrementService(new(MockNumberRepository), "default")
+		stream := &fakeWatchStream{ctx: context.Background()}
+
+		err := service.Watch(&api_v1.WatchRequest{Target: &api_v1.WatchRequest_Prefix{Prefix: "bad prefix"}}, stream)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
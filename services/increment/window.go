@@ -0,0 +1,193 @@
+package increment
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxWindowBuckets caps how many buckets a single query can return
+const maxWindowBuckets = 10000
+
+// windowResolutions maps the API resolutions to bucket widths
+var windowResolutions = map[api_v1.WindowResolution]time.Duration{
+	api_v1.WindowResolution_WINDOW_RESOLUTION_SECOND: time.Second,
+	api_v1.WindowResolution_WINDOW_RESOLUTION_MINUTE: time.Minute,
+	api_v1.WindowResolution_WINDOW_RESOLUTION_HOUR:   time.Hour,
+}
+
+// defaultWindowRetention is how long buckets are kept when a window does not say
+var defaultWindowRetention = map[time.Duration]time.Duration{
+	time.Second: time.Hour,
+	time.Minute: 24 * time.Hour,
+	time.Hour:   30 * 24 * time.Hour,
+}
+
+// fromWindows converts requested windows into their stored form
+// - windows: []*api_v1.Window requested windows
+// Returns []interfaces.Window stored windows, or InvalidArgument if they are not valid
+func fromWindows(windows []*api_v1.Window) ([]interfaces.Window, error) {
+	result := []interfaces.Window{}
+	seen := map[time.Duration]bool{}
+	for _, window := range windows {
+		resolution, ok := windowResolutions[window.GetResolution()]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown window resolution %v", window.GetResolution())
+		}
+		if seen[resolution] {
+			return nil, status.Errorf(codes.InvalidArgument, "window resolution %v is listed twice", window.GetResolution())
+		}
+		seen[resolution] = true
+		retention := defaultWindowRetention[resolution]
+		if window.Retention != nil {
+			if err := window.GetRetention().CheckValid(); err != nil || window.GetRetention().AsDuration() <= 0 {
+				return nil, status.Errorf(codes.InvalidArgument, "window retention %v is not positive", window.GetRetention())
+			}
+			retention = window.GetRetention().AsDuration()
+		}
+		result = append(result, interfaces.Window{Resolution: resolution, Retention: retention})
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// toWindows converts stored windows into their API representation
+// - windows: []interfaces.Window stored windows
+// Returns []*api_v1.Window window messages
+func toWindows(windows []interfaces.Window) []*api_v1.Window {
+	var result []*api_v1.Window
+	for _, window := range windows {
+		result = append(result, &api_v1.Window{
+			Resolution: toResolution(window.Resolution),
+			Retention:  durationpb.New(window.Retention),
+		})
+	}
+	return result
+}
+
+// toResolution converts a bucket width into its API resolution
+// - resolution: time.Duration bucket width
+// Returns api_v1.WindowResolution resolution, unspecified for unknown widths
+func toResolution(resolution time.Duration) api_v1.WindowResolution {
+	for value, width := range windowResolutions {
+		if width == resolution {
+			return value
+		}
+	}
+	return api_v1.WindowResolution_WINDOW_RESOLUTION_UNSPECIFIED
+}
+
+// pickWindow selects the window of a number a query reads
+// - number: *interfaces.Number number to query
+// - requested: api_v1.WindowResolution requested resolution, unspecified picks the finest window
+// Returns the window, InvalidArgument for unknown resolutions, or FailedPrecondition
+// when the number does not keep such a window
+func pickWindow(number *interfaces.Number, requested api_v1.WindowResolution) (interfaces.Window, error) {
+	if requested == api_v1.WindowResolution_WINDOW_RESOLUTION_UNSPECIFIED {
+		if len(number.Windows) == 0 {
+			return interfaces.Window{}, status.Errorf(codes.FailedPrecondition, "counter %s has no windows", number.ID)
+		}
+		finest := number.Windows[0]
+		for _, window := range number.Windows[1:] {
+			if window.Resolution < finest.Resolution {
+				finest = window
+			}
+		}
+		return finest, nil
+	}
+	resolution, ok := windowResolutions[requested]
+	if !ok {
+		return interfaces.Window{}, status.Errorf(codes.InvalidArgument, "unknown window resolution %v", requested)
+	}
+	for _, window := range number.Windows {
+		if window.Resolution == resolution {
+			return window, nil
+		}
+	}
+	return interfaces.Window{}, status.Errorf(codes.FailedPrecondition, "counter %s has no %v window", number.ID, requested)
+}
+
+// queryRange resolves the time range of a query into bucket boundaries
+// - req: *api_v1.QueryWindowRequest request
+// - resolution: time.Duration bucket width
+// - now: time.Time current time
+// Returns the start of the first bucket and the end of the last one, or InvalidArgument
+func queryRange(req *api_v1.QueryWindowRequest, resolution time.Duration, now time.Time) (time.Time, time.Time, error) {
+	var start, end time.Time
+	switch r := req.GetRange().(type) {
+	case *api_v1.QueryWindowRequest_Last:
+		if err := r.Last.CheckValid(); err != nil || r.Last.AsDuration() <= 0 {
+			return start, end, status.Error(codes.InvalidArgument, "last must be a positive duration")
+		}
+		// the current bucket is still filling up, it is part of the range
+		start = now.Add(-r.Last.AsDuration())
+		end = now.Truncate(resolution).Add(resolution)
+	case *api_v1.QueryWindowRequest_Between:
+		if r.Between.GetStart().CheckValid() != nil || r.Between.GetEnd().CheckValid() != nil {
+			return start, end, status.Error(codes.InvalidArgument, "between needs a valid start and end")
+		}
+		start = r.Between.GetStart().AsTime()
+		end = r.Between.GetEnd().AsTime()
+		if !start.Before(end) {
+			return start, end, status.Error(codes.InvalidArgument, "between start must be before its end")
+		}
+	default:
+		return start, end, status.Error(codes.InvalidArgument, "query needs a range")
+	}
+	start = start.Truncate(resolution)
+	if end.Sub(start)/resolution > maxWindowBuckets {
+		return start, end, status.Errorf(codes.InvalidArgument, "range spans more than %d buckets", maxWindowBuckets)
+	}
+	return start, end, nil
+}
+
+// QueryWindow sums the changes of a windowed counter over a time range, the range is
+// widened to whole buckets
+// - ctx: context.Context context
+// - req: *api_v1.QueryWindowRequest request
+// Returns *api_v1.QueryWindowResponse the sum and every bucket of the range, NotFound if the
+// counter does not exist, or FailedPrecondition if it does not keep the requested window
+func (s *ServiceImpl) QueryWindow(ctx context.Context, req *api_v1.QueryWindowRequest) (*api_v1.QueryWindowResponse, error) {
+	name, err := s.resolveName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	number, err := s.repo.FindByID(name)
+	if err != nil {
+		return nil, rpc.ToStatus(err)
+	}
+	window, err := pickWindow(number, req.GetResolution())
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := queryRange(req, window.Resolution, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.repo.Buckets(name, window.Resolution, start, end)
+	if err != nil {
+		slog.Error("Error reading window buckets", "bucket", name, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	sums := make(map[int64]int64, len(stored))
+	for _, bucket := range stored {
+		sums[bucket.Start.Unix()] = bucket.Sum
+	}
+	resp := &api_v1.QueryWindowResponse{Resolution: toResolution(window.Resolution)}
+	for at := start; at.Before(end); at = at.Add(window.Resolution) {
+		sum := sums[at.Unix()]
+		resp.Sum += sum
+		resp.Buckets = append(resp.Buckets, &api_v1.WindowBucket{Start: timestamppb.New(at), Sum: sum})
+	}
+	return resp, nil
+}
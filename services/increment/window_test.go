@@ -0,0 +1,229 @@
+package increment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFromWindows(t *testing.T) {
+	tests := []struct {
+		name     string
+		windows  []*api_v1.Window
+		expected []interfaces.Window
+		wantErr  bool
+	}{
+		{name: "None", expected: nil},
+		{
+			name:     "DefaultRetention",
+			windows:  []*api_v1.Window{{Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_MINUTE}},
+			expected: []interfaces.Window{{Resolution: time.Minute, Retention: 24 * time.Hour}},
+		},
+		{
+			name: "CustomRetention",
+			windows: []*api_v1.Window{
+				{Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_SECOND, Retention: durationpb.New(5 * time.Minute)},
+				{Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_HOUR},
+			},
+			expected: []interfaces.Window{
+				{Resolution: time.Second, Retention: 5 * time.Minute},
+				{Resolution: time.Hour, Retention: 30 * 24 * time.Hour},
+			},
+		},
+		{name: "UnspecifiedResolution", windows: []*api_v1.Window{{}}, wantErr: true},
+		{
+			name: "Duplicate",
+			windows: []*api_v1.Window{
+				{Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_MINUTE},
+				{Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_MINUTE},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "NegativeRetention",
+			windows: []*api_v1.Window{{Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_MINUTE, Retention: durationpb.New(-time.Minute)}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			windows, err := fromWindows(tt.windows)
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, windows)
+			}
+		})
+	}
+}
+
+func TestQueryWindow(t *testing.T) {
+	windowed := &interfaces.Number{
+		ID:     "hits",
+		Number: 10,
+		Windows: []interfaces.Window{
+			{Resolution: time.Hour, Retention: 24 * time.Hour},
+			{Resolution: time.Minute, Retention: time.Hour},
+		},
+	}
+
+	t.Run("sums the last minutes", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("FindByID", "hits").Return(windowed, nil)
+		current := time.Now().Truncate(time.Minute)
+		mockRepo.On("Buckets", "hits", time.Minute, mock.Anything, mock.Anything).Return([]interfaces.WindowBucket{
+			{Start: current.Add(-2 * time.Minute), Sum: 4},
+			{Start: current, Sum: 3},
+		}, nil)
+
+		resp, err := service.QueryWindow(context.Background(), &api_v1.QueryWindowRequest{
+			Name:  "hits",
+			Range: &api_v1.QueryWindowRequest_Last{Last: durationpb.New(5 * time.Minute)},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, api_v1.WindowResolution_WINDOW_RESOLUTION_MINUTE, resp.Resolution)
+		assert.Equal(t, int64(7), resp.Sum)
+		assert.Len(t, resp.Buckets, 6)
+		assert.Equal(t, current.UTC(), resp.Buckets[len(resp.Buckets)-1].Start.AsTime())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns the series between two timestamps", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		end := time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC)
+		mockRepo.On("FindByID", "hits").Return(windowed, nil)
+		mockRepo.On("Buckets", "hits", time.Hour, start, end).Return([]interfaces.WindowBucket{
+			{Start: start.Add(time.Hour), Sum: -2},
+		}, nil)
+
+		resp, err := service.QueryWindow(context.Background(), &api_v1.QueryWindowRequest{
+			Name:       "hits",
+			Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_HOUR,
+			Range:      &api_v1.QueryWindowRequest_Between{Between: &api_v1.TimeRange{Start: timestamppb.New(start.Add(30 * time.Minute)), End: timestamppb.New(end)}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(-2), resp.Sum)
+		sums := []int64{}
+		for _, bucket := range resp.Buckets {
+			sums = append(sums, bucket.Sum)
+		}
+		assert.Equal(t, []int64{0, -2, 0}, sums)
+		assert.Equal(t, start, resp.Buckets[0].Start.AsTime())
+	})
+
+	t.Run("missing window is failed precondition", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("FindByID", "hits").Return(windowed, nil)
+
+		resp, err := service.QueryWindow(context.Background(), &api_v1.QueryWindowRequest{
+			Name:       "hits",
+			Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_SECOND,
+			Range:      &api_v1.QueryWindowRequest_Last{Last: durationpb.New(time.Minute)},
+		})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("counter without windows is failed precondition", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("FindByID", "default").Return(&interfaces.Number{ID: "default"}, nil)
+
+		resp, err := service.QueryWindow(context.Background(), &api_v1.QueryWindowRequest{
+			Range: &api_v1.QueryWindowRequest_Last{Last: durationpb.New(time.Minute)},
+		})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("invalid ranges are rejected", func(t *testing.T) {
+		now := time.Now()
+		ranges := map[string]*api_v1.QueryWindowRequest{
+			"none":      {Name: "hits"},
+			"zero last": {Name: "hits", Range: &api_v1.QueryWindowRequest_Last{Last: durationpb.New(0)}},
+			"reversed":  {Name: "hits", Range: &api_v1.QueryWindowRequest_Between{Between: &api_v1.TimeRange{Start: timestamppb.New(now), End: timestamppb.New(now.Add(-time.Hour))}}},
+			"no end":    {Name: "hits", Range: &api_v1.QueryWindowRequest_Between{Between: &api_v1.TimeRange{Start: timestamppb.New(now)}}},
+			"too many":  {Name: "hits", Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_MINUTE, Range: &api_v1.QueryWindowRequest_Last{Last: durationpb.New(365 * 24 * time.Hour)}},
+		}
+		for name, req := range ranges {
+			mockRepo := new(MockNumberRepository)
+			service := NewIncrementService(mockRepo, "default")
+			mockRepo.On("FindByID", "hits").Return(windowed, nil)
+
+			resp, err := service.QueryWindow(context.Background(), req)
+
+			assert.Nil(t, resp, name)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+	})
+
+	t.Run("missing counter is not found", func(t *testing.T) {
+		mockRepo := new(MockNumberRepository)
+		service := NewIncrementService(mockRepo, "default")
+		mockRepo.On("FindByID", "missing").Return((*interfaces.Number)(nil), interfaces.ErrNotFound)
+
+		resp, err := service.QueryWindow(context.Background(), &api_v1.QueryWindowRequest{Name: "missing"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestWindowsCountRequestedChanges(t *testing.T) {
+	service := NewIncrementService(legacyRepository(t, nil), "default")
+	ctx := context.Background()
+	_, err := service.CreateCounter(ctx, &api_v1.CreateCounterRequest{
+		Name:    "digits",
+		Bounds:  &api_v1.Bounds{Max: proto.Uint64(9), Policy: api_v1.OverflowPolicy_OVERFLOW_POLICY_WRAP},
+		Windows: []*api_v1.Window{{Resolution: api_v1.WindowResolution_WINDOW_RESOLUTION_MINUTE}},
+	})
+	require.NoError(t, err)
+	lastFiveMinutes := func() int64 {
+		resp, err := service.QueryWindow(ctx, &api_v1.QueryWindowRequest{
+			Name:  "digits",
+			Range: &api_v1.QueryWindowRequest_Last{Last: durationpb.New(5 * time.Minute)},
+		})
+		require.NoError(t, err)
+		return resp.Sum
+	}
+
+	t.Run("bounded wrap counts one increment", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			_, err := service.Increment(ctx, &api_v1.IncrementRequest{Name: "digits"})
+			require.NoError(t, err)
+		}
+		counter, err := service.Get(ctx, &api_v1.GetRequest{Name: "digits"})
+		require.NoError(t, err)
+		assert.Zero(t, counter.Value, "the tenth increment wraps 9 to 0")
+		assert.Equal(t, int64(10), lastFiveMinutes())
+	})
+
+	t.Run("reset is not a change", func(t *testing.T) {
+		_, err := service.Add(ctx, &api_v1.AddRequest{Name: "digits", Delta: 7})
+		require.NoError(t, err)
+		_, err = service.Reset(ctx, &api_v1.ResetRequest{Name: "digits"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(17), lastFiveMinutes())
+	})
+}
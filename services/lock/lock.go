@@ -0,0 +1,186 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// fenceKeyPrefix prefixes the counters fencing tokens are taken from
+	fenceKeyPrefix = interfaces.ReservedKeyPrefix + "fence/"
+	// minLeaseTTL is the shortest lease, the store tracks expiry in whole seconds
+	minLeaseTTL = time.Second
+)
+
+// ServiceImpl is the implementation of LockServiceServer
+type ServiceImpl struct {
+	api_v1.UnimplementedLockServiceServer
+	repo     interfaces.INumberRepository
+	releases *releases
+}
+
+// NewLockService creates a new ServiceImpl
+// - repo: INumberRepository repository leases and fencing counters are stored in
+func NewLockService(repo interfaces.INumberRepository) *ServiceImpl {
+	return &ServiceImpl{repo: repo, releases: newReleases()}
+}
+
+// validate checks the name and ttl of a request
+// - name: string lock name
+// - ttl: *durationpb.Duration lease ttl, nil when the request has none
+// Returns the ttl, or InvalidArgument if the name or ttl is not valid
+func validate(name string, ttl *durationpb.Duration) (time.Duration, error) {
+	if !rpc.ValidName(name) {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid lock name %q", name)
+	}
+	if ttl.CheckValid() != nil || ttl.AsDuration() < minLeaseTTL {
+		return 0, status.Errorf(codes.InvalidArgument, "lease ttl must be at least %s", minLeaseTTL)
+	}
+	return ttl.AsDuration(), nil
+}
+
+// acquire takes a lock in a single transaction, the fencing token is taken from
+// a counter kept for the lock so it keeps growing after leases expire
+// - name: string lock name
+// - owner: string holder description, it does not identify the holder so a held lock is refused whoever asks
+// - ttl: time.Duration lease ttl
+// Returns the new lease, or ErrLocked together with the lease currently holding the lock
+func (s *ServiceImpl) acquire(name string, owner string, ttl time.Duration) (*interfaces.Lease, *interfaces.Lease, error) {
+	var lease, holder *interfaces.Lease
+	err := s.repo.Transact(func(txn interfaces.INumberTxn) error {
+		lease, holder = nil, nil
+		held, err := txn.GetLease(name)
+		if err != nil {
+			return err
+		}
+		if held != nil {
+			holder = held
+			return fmt.Errorf("%w: %s is held by %q", interfaces.ErrLocked, name, held.Owner)
+		}
+		fence, err := txn.Get(fenceKeyPrefix + name)
+		if err != nil {
+			return err
+		}
+		fence.Number++
+		if err := txn.Put(fence); err != nil {
+			return err
+		}
+		lease = &interfaces.Lease{Name: name, Owner: owner, Token: fence.Number}
+		return txn.PutLease(lease, ttl)
+	})
+	if err != nil {
+		return nil, holder, err
+	}
+	return lease, nil, nil
+}
+
+// Acquire takes a free lock
+// - ctx: context.Context context
+// - req: *api_v1.AcquireRequest request
+// Returns *api_v1.Lease the lease, or FailedPrecondition if the lock is held, whatever the
+// owner of the lease holding it
+func (s *ServiceImpl) Acquire(ctx context.Context, req *api_v1.AcquireRequest) (*api_v1.Lease, error) {
+	ttl, err := validate(req.GetName(), req.GetTtl())
+	if err != nil {
+		return nil, err
+	}
+	lease, _, err := s.acquire(req.GetName(), req.GetOwner(), ttl)
+	if err != nil {
+		slog.Warn("Error acquiring lock", "lock", req.GetName(), "owner", req.GetOwner(), "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	slog.Info("Acquired lock", "lock", lease.Name, "owner", lease.Owner, "token", lease.Token)
+	return toLease(lease), nil
+}
+
+// Renew extends a lease that is still held
+// - ctx: context.Context context
+// - req: *api_v1.RenewRequest request
+// Returns *api_v1.Lease the renewed lease, NotFound if it expired, or FailedPrecondition
+// if the lock was taken over by another lease
+func (s *ServiceImpl) Renew(ctx context.Context, req *api_v1.RenewRequest) (*api_v1.Lease, error) {
+	ttl, err := validate(req.GetName(), req.GetTtl())
+	if err != nil {
+		return nil, err
+	}
+	var lease *interfaces.Lease
+	err = s.repo.Transact(func(txn interfaces.INumberTxn) error {
+		var err error
+		lease, err = heldLease(txn, req.GetName(), req.GetFencingToken())
+		if err != nil {
+			return err
+		}
+		return txn.PutLease(lease, ttl)
+	})
+	if err != nil {
+		slog.Warn("Error renewing lock", "lock", req.GetName(), "token", req.GetFencingToken(), "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	return toLease(lease), nil
+}
+
+// Release frees a lock and wakes up the callers waiting for it
+// - ctx: context.Context context
+// - req: *api_v1.ReleaseRequest request
+// Returns NotFound if the lease expired, or FailedPrecondition if the lock was taken
+// over by another lease
+func (s *ServiceImpl) Release(ctx context.Context, req *api_v1.ReleaseRequest) (*emptypb.Empty, error) {
+	if !rpc.ValidName(req.GetName()) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid lock name %q", req.GetName())
+	}
+	err := s.repo.Transact(func(txn interfaces.INumberTxn) error {
+		if _, err := heldLease(txn, req.GetName(), req.GetFencingToken()); err != nil {
+			return err
+		}
+		return txn.DeleteLease(req.GetName())
+	})
+	if err != nil {
+		slog.Warn("Error releasing lock", "lock", req.GetName(), "token", req.GetFencingToken(), "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	s.releases.notify(req.GetName())
+	slog.Info("Released lock", "lock", req.GetName(), "token", req.GetFencingToken())
+	return &emptypb.Empty{}, nil
+}
+
+// heldLease reads the lease of a lock and checks that it is the expected one
+// - txn: interfaces.INumberTxn transaction
+// - name: string lock name
+// - token: uint64 fencing token of the expected lease
+// Returns the lease, ErrNotFound if the lock is free, or ErrLocked if another lease holds it
+func heldLease(txn interfaces.INumberTxn, name string, token uint64) (*interfaces.Lease, error) {
+	lease, err := txn.GetLease(name)
+	if err != nil {
+		return nil, err
+	}
+	if lease == nil {
+		return nil, fmt.Errorf("%w: no lease on %s", interfaces.ErrNotFound, name)
+	}
+	if lease.Token != token {
+		return nil, fmt.Errorf("%w: %s is held with token %d", interfaces.ErrLocked, name, lease.Token)
+	}
+	return lease, nil
+}
+
+// toLease converts a stored lease into its API representation
+// - lease: *interfaces.Lease stored lease
+// Returns *api_v1.Lease lease message
+func toLease(lease *interfaces.Lease) *api_v1.Lease {
+	return &api_v1.Lease{
+		Name:         lease.Name,
+		Owner:        lease.Owner,
+		FencingToken: lease.Token,
+		ExpireTime:   timestamppb.New(lease.ExpiresAt),
+	}
+}
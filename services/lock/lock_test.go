@@ -0,0 +1,117 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/repositories/number"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// newTestService creates a service storing its leases in a temporary database
+func newTestService(t *testing.T) *ServiceImpl {
+	db, err := badger.Open(badger.DefaultOptions(t.TempDir()))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewLockService(number.NewBadgerNumberRepository(db))
+}
+
+func TestAcquire(t *testing.T) {
+	service := newTestService(t)
+	ctx := context.Background()
+	ttl := durationpb.New(time.Minute)
+
+	first, err := service.Acquire(ctx, &api_v1.AcquireRequest{Name: "cron", Owner: "job-1", Ttl: ttl})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), first.FencingToken)
+	assert.Equal(t, "job-1", first.Owner)
+	assert.True(t, first.ExpireTime.AsTime().After(time.Now().Add(59*time.Second)))
+
+	t.Run("held lock is failed precondition", func(t *testing.T) {
+		lease, err := service.Acquire(ctx, &api_v1.AcquireRequest{Name: "cron", Owner: "job-2", Ttl: ttl})
+		assert.Nil(t, lease)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		lease, err = service.Acquire(ctx, &api_v1.AcquireRequest{Name: "cron", Ttl: ttl})
+		assert.Nil(t, lease)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), "anonymous callers never share a lease")
+	})
+
+	t.Run("same owner is failed precondition", func(t *testing.T) {
+		// two instances of one job sending the same owner must not both hold the lock
+		lease, err := service.Acquire(ctx, &api_v1.AcquireRequest{Name: "cron", Owner: "job-1", Ttl: durationpb.New(time.Hour)})
+		assert.Nil(t, lease)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		renewed, err := service.Renew(ctx, &api_v1.RenewRequest{Name: "cron", FencingToken: first.FencingToken, Ttl: durationpb.New(time.Hour)})
+		require.NoError(t, err)
+		assert.Equal(t, first.FencingToken, renewed.FencingToken)
+		assert.True(t, renewed.ExpireTime.AsTime().After(time.Now().Add(59*time.Minute)))
+	})
+
+	t.Run("tokens grow with every acquisition", func(t *testing.T) {
+		_, err := service.Release(ctx, &api_v1.ReleaseRequest{Name: "cron", FencingToken: first.FencingToken})
+		require.NoError(t, err)
+
+		lease, err := service.Acquire(ctx, &api_v1.AcquireRequest{Name: "cron", Owner: "job-2", Ttl: ttl})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), lease.FencingToken)
+	})
+
+	t.Run("locks are independent", func(t *testing.T) {
+		lease, err := service.Acquire(ctx, &api_v1.AcquireRequest{Name: "backup", Ttl: ttl})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), lease.FencingToken)
+	})
+
+	t.Run("invalid requests are rejected", func(t *testing.T) {
+		for name, req := range map[string]*api_v1.AcquireRequest{
+			"no name":   {Ttl: ttl},
+			"bad name":  {Name: "!cron", Ttl: ttl},
+			"no ttl":    {Name: "cron"},
+			"short ttl": {Name: "cron", Ttl: durationpb.New(time.Millisecond)},
+		} {
+			lease, err := service.Acquire(ctx, req)
+			assert.Nil(t, lease, name)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+	})
+}
+
+func TestRenewAndRelease(t *testing.T) {
+	service := newTestService(t)
+	ctx := context.Background()
+	lease, err := service.Acquire(ctx, &api_v1.AcquireRequest{Name: "cron", Ttl: durationpb.New(time.Minute)})
+	require.NoError(t, err)
+
+	t.Run("renews held lease", func(t *testing.T) {
+		renewed, err := service.Renew(ctx, &api_v1.RenewRequest{Name: "cron", FencingToken: lease.FencingToken, Ttl: durationpb.New(time.Hour)})
+		require.NoError(t, err)
+		assert.Equal(t, lease.FencingToken, renewed.FencingToken)
+		assert.True(t, renewed.ExpireTime.AsTime().After(lease.ExpireTime.AsTime()))
+	})
+
+	t.Run("other token is failed precondition", func(t *testing.T) {
+		_, err := service.Renew(ctx, &api_v1.RenewRequest{Name: "cron", FencingToken: lease.FencingToken + 1, Ttl: durationpb.New(time.Hour)})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		_, err = service.Release(ctx, &api_v1.ReleaseRequest{Name: "cron", FencingToken: lease.FencingToken + 1})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("released lease is not found", func(t *testing.T) {
+		_, err := service.Release(ctx, &api_v1.ReleaseRequest{Name: "cron", FencingToken: lease.FencingToken})
+		require.NoError(t, err)
+
+		_, err = service.Renew(ctx, &api_v1.RenewRequest{Name: "cron", FencingToken: lease.FencingToken, Ttl: durationpb.New(time.Hour)})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = service.Release(ctx, &api_v1.ReleaseRequest{Name: "cron", FencingToken: lease.FencingToken})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -0,0 +1,116 @@
+package lock
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// minRetryDelay keeps waiters from spinning while an expired lease is being dropped
+const minRetryDelay = 10 * time.Millisecond
+
+// releases wakes up the callers waiting for a lock when it is released
+type releases struct {
+	mu      sync.Mutex
+	waiting map[string]chan struct{}
+}
+
+// newReleases creates an empty releases
+func newReleases() *releases {
+	return &releases{waiting: map[string]chan struct{}{}}
+}
+
+// wait returns a channel that is closed on the next release of a lock
+// - name: string lock name
+func (r *releases) wait(name string) <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ch, ok := r.waiting[name]
+	if !ok {
+		ch = make(chan struct{})
+		r.waiting[name] = ch
+	}
+	return ch
+}
+
+// notify wakes up every caller waiting for a lock
+// - name: string lock name
+func (r *releases) notify(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ch, ok := r.waiting[name]; ok {
+		close(ch)
+		delete(r.waiting, name)
+	}
+}
+
+// AcquireWait waits until a lock can be taken, it retries when the lock is released
+// through this server or when the lease holding it expires
+// - req: *api_v1.AcquireRequest request
+// - stream: grpc.ServerStreamingServer[api_v1.AcquireEvent] stream the events are sent to
+// Returns nil once the lock is acquired, or the status of the failure
+func (s *ServiceImpl) AcquireWait(req *api_v1.AcquireRequest, stream grpc.ServerStreamingServer[api_v1.AcquireEvent]) error {
+	ctx := stream.Context()
+	ttl, err := validate(req.GetName(), req.GetTtl())
+	if err != nil {
+		return err
+	}
+	var reported uint64
+	for {
+		// subscribe before trying so a release in between is not missed
+		released := s.releases.wait(req.GetName())
+		lease, holder, err := s.acquire(req.GetName(), req.GetOwner(), ttl)
+		if err == nil {
+			event := &api_v1.AcquireEvent{Type: api_v1.AcquireEvent_TYPE_ACQUIRED, Lease: toLease(lease)}
+			if err := stream.Send(event); err != nil {
+				s.abandon(lease)
+				return err
+			}
+			slog.Info("Acquired lock after waiting", "lock", lease.Name, "owner", lease.Owner, "token", lease.Token)
+			return nil
+		}
+		if !errors.Is(err, interfaces.ErrLocked) {
+			slog.Warn("Error acquiring lock", "lock", req.GetName(), "owner", req.GetOwner(), "error", err)
+			return rpc.ToStatus(err)
+		}
+		if holder.Token != reported {
+			event := &api_v1.AcquireEvent{Type: api_v1.AcquireEvent_TYPE_WAITING, Lease: toLease(holder)}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			reported = holder.Token
+		}
+		timer := time.NewTimer(max(time.Until(holder.ExpiresAt), minRetryDelay))
+		select {
+		case <-released:
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		timer.Stop()
+	}
+}
+
+// abandon releases a lease whose holder went away before it learned about it
+// - lease: *interfaces.Lease lease to release
+func (s *ServiceImpl) abandon(lease *interfaces.Lease) {
+	err := s.repo.Transact(func(txn interfaces.INumberTxn) error {
+		if _, err := heldLease(txn, lease.Name, lease.Token); err != nil {
+			return err
+		}
+		return txn.DeleteLease(lease.Name)
+	})
+	if err != nil {
+		slog.Warn("Failed to release abandoned lock", "lock", lease.Name, "token", lease.Token, "error", err)
+		return
+	}
+	s.releases.notify(lease.Name)
+}
@@ -0,0 +1,134 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// fakeAcquireStream records the events sent on an acquire stream
+type fakeAcquireStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	mu     sync.Mutex
+	events []*api_v1.AcquireEvent
+}
+
+func (f *fakeAcquireStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeAcquireStream) Send(event *api_v1.AcquireEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *fakeAcquireStream) sent() []*api_v1.AcquireEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*api_v1.AcquireEvent{}, f.events...)
+}
+
+// waitInBackground runs AcquireWait and returns a channel receiving its result
+func waitInBackground(service *ServiceImpl, req *api_v1.AcquireRequest, stream *fakeAcquireStream) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- service.AcquireWait(req, stream)
+	}()
+	return done
+}
+
+func TestAcquireWait(t *testing.T) {
+
+	t.Run("free lock is acquired right away", func(t *testing.T) {
+		service := newTestService(t)
+		stream := &fakeAcquireStream{ctx: context.Background()}
+
+		err := service.AcquireWait(&api_v1.AcquireRequest{Name: "cron", Ttl: durationpb.New(time.Minute)}, stream)
+
+		require.NoError(t, err)
+		events := stream.sent()
+		require.Len(t, events, 1)
+		assert.Equal(t, api_v1.AcquireEvent_TYPE_ACQUIRED, events[0].Type)
+		assert.Equal(t, uint64(1), events[0].Lease.FencingToken)
+	})
+
+	t.Run("release wakes up the waiter", func(t *testing.T) {
+		service := newTestService(t)
+		ctx := context.Background()
+		held, err := service.Acquire(ctx, &api_v1.AcquireRequest{Name: "cron", Owner: "job-1", Ttl: durationpb.New(time.Hour)})
+		require.NoError(t, err)
+
+		stream := &fakeAcquireStream{ctx: ctx}
+		done := waitInBackground(service, &api_v1.AcquireRequest{Name: "cron", Owner: "job-2", Ttl: durationpb.New(time.Minute)}, stream)
+		require.Eventually(t, func() bool { return len(stream.sent()) == 1 }, time.Second, 10*time.Millisecond)
+		waiting := stream.sent()[0]
+		assert.Equal(t, api_v1.AcquireEvent_TYPE_WAITING, waiting.Type)
+		assert.Equal(t, "job-1", waiting.Lease.Owner)
+
+		_, err = service.Release(ctx, &api_v1.ReleaseRequest{Name: "cron", FencingToken: held.FencingToken})
+		require.NoError(t, err)
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("waiter was not woken up by the release")
+		}
+		events := stream.sent()
+		require.Len(t, events, 2)
+		assert.Equal(t, api_v1.AcquireEvent_TYPE_ACQUIRED, events[1].Type)
+		assert.Equal(t, "job-2", events[1].Lease.Owner)
+		assert.Equal(t, held.FencingToken+1, events[1].Lease.FencingToken)
+	})
+
+	t.Run("expiry releases the lock", func(t *testing.T) {
+		service := newTestService(t)
+		ctx := context.Background()
+		_, err := service.Acquire(ctx, &api_v1.AcquireRequest{Name: "cron", Ttl: durationpb.New(time.Second)})
+		require.NoError(t, err)
+
+		stream := &fakeAcquireStream{ctx: ctx}
+		done := waitInBackground(service, &api_v1.AcquireRequest{Name: "cron", Ttl: durationpb.New(time.Minute)}, stream)
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("waiter did not get the expired lock")
+		}
+		events := stream.sent()
+		assert.Equal(t, api_v1.AcquireEvent_TYPE_ACQUIRED, events[len(events)-1].Type)
+		assert.Equal(t, uint64(2), events[len(events)-1].Lease.FencingToken)
+	})
+
+	t.Run("cancelled wait ends", func(t *testing.T) {
+		service := newTestService(t)
+		_, err := service.Acquire(context.Background(), &api_v1.AcquireRequest{Name: "cron", Ttl: durationpb.New(time.Hour)})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &fakeAcquireStream{ctx: ctx}
+		done := waitInBackground(service, &api_v1.AcquireRequest{Name: "cron", Ttl: durationpb.New(time.Minute)}, stream)
+		require.Eventually(t, func() bool { return len(stream.sent()) == 1 }, time.Second, 10*time.Millisecond)
+		cancel()
+
+		select {
+		case err := <-done:
+			assert.Equal(t, codes.Canceled, status.Code(err))
+		case <-time.After(time.Second):
+			t.Fatal("wait did not end after cancel")
+		}
+	})
+}
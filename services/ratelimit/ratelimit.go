@@ -0,0 +1,197 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"path"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// maxLogLimit caps the limit of sliding window logs, their state grows with every admitted request
+const maxLogLimit = 10000
+
+// ServiceImpl is the implementation of RateLimitServiceServer
+type ServiceImpl struct {
+	api_v1.UnimplementedRateLimitServiceServer
+	repo  interfaces.IRateLimitRepository
+	rules []interfaces.RateLimitRule
+	now   func() time.Time
+}
+
+// NewRateLimitService creates a new ServiceImpl
+// - repo: IRateLimitRepository rate limit state repository
+// - rules: []interfaces.RateLimitRule rules in the order keys are matched against them
+// Returns the service, or an error if a rule is not valid
+func NewRateLimitService(repo interfaces.IRateLimitRepository, rules []interfaces.RateLimitRule) (*ServiceImpl, error) {
+	for i, rule := range rules {
+		if err := validateRule(rule); err != nil {
+			return nil, fmt.Errorf("rate limit rule %d (%s): %w", i, rule.Pattern, err)
+		}
+	}
+	return &ServiceImpl{repo: repo, rules: rules, now: time.Now}, nil
+}
+
+// validateRule checks that a rule can be applied
+// - rule: interfaces.RateLimitRule rule to check
+// Returns an error describing the first problem found
+func validateRule(rule interfaces.RateLimitRule) error {
+	if _, err := path.Match(rule.Pattern, ""); err != nil {
+		return err
+	}
+	if rule.Limit == 0 {
+		return errors.New("limit must be positive")
+	}
+	if rule.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	switch rule.Algorithm {
+	case interfaces.TokenBucket:
+		return nil
+	case interfaces.SlidingWindowLog:
+		if rule.Limit > maxLogLimit {
+			return fmt.Errorf("sliding window log limit is above %d", maxLogLimit)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown algorithm %q", rule.Algorithm)
+	}
+}
+
+// matchRule finds the first rule whose pattern matches a key
+// - key: string rate limited key
+// Returns the rule, or false when no rule matches
+func (s *ServiceImpl) matchRule(key string) (interfaces.RateLimitRule, bool) {
+	for _, rule := range s.rules {
+		if ok, _ := path.Match(rule.Pattern, key); ok {
+			return rule, true
+		}
+	}
+	return interfaces.RateLimitRule{}, false
+}
+
+// decision is the outcome of applying a rule to a request
+type decision struct {
+	allowed    bool
+	remaining  uint64
+	retryAfter time.Duration
+}
+
+// Allow spends cost from the limit of a key using the algorithm of the first matching rule
+// - ctx: context.Context context
+// - req: *api_v1.AllowRequest request
+// Returns *api_v1.AllowResponse the decision, InvalidArgument for malformed keys or costs
+// above the limit, or FailedPrecondition if no rule matches the key
+func (s *ServiceImpl) Allow(ctx context.Context, req *api_v1.AllowRequest) (*api_v1.AllowResponse, error) {
+	key := req.GetKey()
+	if !rpc.ValidName(key) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid rate limit key %q", key)
+	}
+	rule, ok := s.matchRule(key)
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "no rate limit rule matches key %q", key)
+	}
+	cost := max(req.GetCost(), 1)
+	if cost > rule.Limit {
+		return nil, status.Errorf(codes.InvalidArgument, "cost %d is above the limit %d of key %q", cost, rule.Limit, key)
+	}
+
+	var result decision
+	// an idle key is back to a full limit after one interval, its state can go
+	_, err := s.repo.Update(key, rule.Interval, func(state *interfaces.RateLimitState) error {
+		now := s.now()
+		switch rule.Algorithm {
+		case interfaces.SlidingWindowLog:
+			result = slidingWindowLog(state, rule, cost, now)
+		default:
+			result = tokenBucket(state, rule, cost, now)
+		}
+		state.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		slog.Error("Error applying rate limit", "key", key, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+
+	resp := &api_v1.AllowResponse{Allowed: result.allowed, Remaining: result.remaining, Limit: rule.Limit}
+	if !result.allowed {
+		resp.RetryAfter = durationpb.New(result.retryAfter)
+		slog.Info("Rate limited", "key", key, "pattern", rule.Pattern, "retry_after", result.retryAfter)
+	}
+	return resp, nil
+}
+
+// tokenBucket refills the bucket for the time passed since the last request and spends cost from it
+// - state: *interfaces.RateLimitState stored state, updated in place
+// - rule: interfaces.RateLimitRule rule of the key
+// - cost: uint64 tokens the request needs
+// - now: time.Time time of the request
+// Returns the decision
+func tokenBucket(state *interfaces.RateLimitState, rule interfaces.RateLimitRule, cost uint64, now time.Time) decision {
+	capacity := float64(rule.Limit)
+	perSecond := capacity / rule.Interval.Seconds()
+	tokens := capacity
+	if !state.UpdatedAt.IsZero() {
+		elapsed := max(now.Sub(state.UpdatedAt).Seconds(), 0)
+		tokens = min(state.Tokens+elapsed*perSecond, capacity)
+	}
+	result := decision{allowed: tokens >= float64(cost)}
+	if result.allowed {
+		tokens -= float64(cost)
+	} else {
+		missing := float64(cost) - tokens
+		result.retryAfter = time.Duration(math.Ceil(missing / perSecond * float64(time.Second)))
+	}
+	state.Tokens = tokens
+	state.Log = nil
+	result.remaining = uint64(math.Floor(tokens))
+	return result
+}
+
+// slidingWindowLog drops the requests that left the window and admits cost if it still fits
+// - state: *interfaces.RateLimitState stored state, updated in place
+// - rule: interfaces.RateLimitRule rule of the key
+// - cost: uint64 cost of the request
+// - now: time.Time time of the request
+// Returns the decision
+func slidingWindowLog(state *interfaces.RateLimitState, rule interfaces.RateLimitRule, cost uint64, now time.Time) decision {
+	windowStart := now.Add(-rule.Interval)
+	log := []interfaces.RateLimitEntry{}
+	var used uint64
+	for _, entry := range state.Log {
+		if entry.At.After(windowStart) {
+			log = append(log, entry)
+			used += entry.Cost
+		}
+	}
+	result := decision{allowed: used+cost <= rule.Limit}
+	if result.allowed {
+		log = append(log, interfaces.RateLimitEntry{At: now, Cost: cost})
+		used += cost
+	} else {
+		// wait until enough of the oldest requests have left the window
+		freed := uint64(0)
+		for _, entry := range log {
+			freed += entry.Cost
+			if used-freed+cost <= rule.Limit {
+				result.retryAfter = entry.At.Add(rule.Interval).Sub(now)
+				break
+			}
+		}
+	}
+	state.Log = log
+	state.Tokens = 0
+	// a rule lowered since the requests were admitted can leave more than the limit in the window
+	result.remaining = rule.Limit - min(used, rule.Limit)
+	return result
+}
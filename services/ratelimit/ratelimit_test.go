@@ -0,0 +1,231 @@
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRateLimitRepository keeps rate limit states in a map
+type memoryRateLimitRepository struct {
+	states map[string]interfaces.RateLimitState
+	ttls   map[string]time.Duration
+	err    error
+}
+
+func newMemoryRateLimitRepository() *memoryRateLimitRepository {
+	return &memoryRateLimitRepository{states: map[string]interfaces.RateLimitState{}, ttls: map[string]time.Duration{}}
+}
+
+func (m *memoryRateLimitRepository) Update(key string, ttl time.Duration, fn func(state *interfaces.RateLimitState) error) (*interfaces.RateLimitState, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	state, ok := m.states[key]
+	if !ok {
+		state = interfaces.RateLimitState{Key: key}
+	}
+	if err := fn(&state); err != nil {
+		return nil, err
+	}
+	m.states[key] = state
+	m.ttls[key] = ttl
+	return &state, nil
+}
+
+// newTestService creates a service whose clock is moved by the returned function
+func newTestService(t *testing.T, repo interfaces.IRateLimitRepository, rules ...interfaces.RateLimitRule) (*ServiceImpl, func(d time.Duration)) {
+	service, err := NewRateLimitService(repo, rules)
+	require.NoError(t, err)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	return service, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestNewRateLimitService(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    interfaces.RateLimitRule
+		wantErr bool
+	}{
+		{name: "TokenBucket", rule: interfaces.RateLimitRule{Pattern: "*", Algorithm: interfaces.TokenBucket, Limit: 10, Interval: time.Second}},
+		{name: "SlidingWindowLog", rule: interfaces.RateLimitRule{Pattern: "login/*", Algorithm: interfaces.SlidingWindowLog, Limit: 5, Interval: time.Minute}},
+		{name: "BadPattern", rule: interfaces.RateLimitRule{Pattern: "[", Algorithm: interfaces.TokenBucket, Limit: 10, Interval: time.Second}, wantErr: true},
+		{name: "ZeroLimit", rule: interfaces.RateLimitRule{Pattern: "*", Algorithm: interfaces.TokenBucket, Interval: time.Second}, wantErr: true},
+		{name: "ZeroInterval", rule: interfaces.RateLimitRule{Pattern: "*", Algorithm: interfaces.TokenBucket, Limit: 10}, wantErr: true},
+		{name: "UnknownAlgorithm", rule: interfaces.RateLimitRule{Pattern: "*", Algorithm: "leaky", Limit: 10, Interval: time.Second}, wantErr: true},
+		{name: "LogTooLarge", rule: interfaces.RateLimitRule{Pattern: "*", Algorithm: interfaces.SlidingWindowLog, Limit: maxLogLimit + 1, Interval: time.Second}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := NewRateLimitService(newMemoryRateLimitRepository(), []interfaces.RateLimitRule{tt.rule})
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, service)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, service)
+			}
+		})
+	}
+}
+
+func TestAllow(t *testing.T) {
+	tokenBucketRule := interfaces.RateLimitRule{Pattern: "api/*", Algorithm: interfaces.TokenBucket, Limit: 10, Interval: 10 * time.Second}
+	slidingWindowRule := interfaces.RateLimitRule{Pattern: "login/*", Algorithm: interfaces.SlidingWindowLog, Limit: 3, Interval: time.Minute}
+
+	t.Run("token bucket spends and refills", func(t *testing.T) {
+		repo := newMemoryRateLimitRepository()
+		service, advance := newTestService(t, repo, tokenBucketRule)
+		ctx := context.Background()
+
+		resp, err := service.Allow(ctx, &api_v1.AllowRequest{Key: "api/a", Cost: 8})
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+		assert.Equal(t, uint64(2), resp.Remaining)
+		assert.Equal(t, uint64(10), resp.Limit)
+		assert.Nil(t, resp.RetryAfter)
+
+		resp, err = service.Allow(ctx, &api_v1.AllowRequest{Key: "api/a", Cost: 5})
+		require.NoError(t, err)
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, uint64(2), resp.Remaining)
+		assert.Equal(t, 3*time.Second, resp.RetryAfter.AsDuration())
+
+		advance(3 * time.Second)
+		resp, err = service.Allow(ctx, &api_v1.AllowRequest{Key: "api/a", Cost: 5})
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+		assert.Equal(t, uint64(0), resp.Remaining)
+		assert.Equal(t, tokenBucketRule.Interval, repo.ttls["api/a"])
+
+		advance(time.Hour)
+		resp, err = service.Allow(ctx, &api_v1.AllowRequest{Key: "api/a"})
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+		assert.Equal(t, uint64(9), resp.Remaining, "refills stop at the capacity")
+	})
+
+	t.Run("keys are limited separately", func(t *testing.T) {
+		service, _ := newTestService(t, newMemoryRateLimitRepository(), tokenBucketRule)
+
+		_, err := service.Allow(context.Background(), &api_v1.AllowRequest{Key: "api/a", Cost: 10})
+		require.NoError(t, err)
+		resp, err := service.Allow(context.Background(), &api_v1.AllowRequest{Key: "api/b", Cost: 10})
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("sliding window log admits up to the limit per window", func(t *testing.T) {
+		service, advance := newTestService(t, newMemoryRateLimitRepository(), tokenBucketRule, slidingWindowRule)
+		ctx := context.Background()
+
+		for i := 0; i < 3; i++ {
+			resp, err := service.Allow(ctx, &api_v1.AllowRequest{Key: "login/bob"})
+			require.NoError(t, err)
+			assert.True(t, resp.Allowed)
+			assert.Equal(t, uint64(2-i), resp.Remaining)
+			advance(10 * time.Second)
+		}
+
+		resp, err := service.Allow(ctx, &api_v1.AllowRequest{Key: "login/bob"})
+		require.NoError(t, err)
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, uint64(0), resp.Remaining)
+		assert.Equal(t, 30*time.Second, resp.RetryAfter.AsDuration())
+
+		advance(30 * time.Second)
+		resp, err = service.Allow(ctx, &api_v1.AllowRequest{Key: "login/bob"})
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+		assert.Equal(t, uint64(0), resp.Remaining)
+	})
+
+	t.Run("sliding window retry waits for enough cost to leave", func(t *testing.T) {
+		service, advance := newTestService(t, newMemoryRateLimitRepository(), slidingWindowRule)
+		ctx := context.Background()
+
+		_, err := service.Allow(ctx, &api_v1.AllowRequest{Key: "login/bob"})
+		require.NoError(t, err)
+		advance(20 * time.Second)
+		_, err = service.Allow(ctx, &api_v1.AllowRequest{Key: "login/bob", Cost: 2})
+		require.NoError(t, err)
+
+		resp, err := service.Allow(ctx, &api_v1.AllowRequest{Key: "login/bob", Cost: 3})
+		require.NoError(t, err)
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, time.Minute, resp.RetryAfter.AsDuration())
+	})
+
+	t.Run("first matching rule wins", func(t *testing.T) {
+		catchAll := interfaces.RateLimitRule{Pattern: "*/*", Algorithm: interfaces.TokenBucket, Limit: 1000, Interval: time.Second}
+		service, _ := newTestService(t, newMemoryRateLimitRepository(), slidingWindowRule, catchAll)
+
+		resp, err := service.Allow(context.Background(), &api_v1.AllowRequest{Key: "login/bob"})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), resp.Limit)
+		resp, err = service.Allow(context.Background(), &api_v1.AllowRequest{Key: "other/bob"})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1000), resp.Limit)
+	})
+
+	t.Run("unmatched key is failed precondition", func(t *testing.T) {
+		service, _ := newTestService(t, newMemoryRateLimitRepository(), tokenBucketRule)
+
+		resp, err := service.Allow(context.Background(), &api_v1.AllowRequest{Key: "other/a"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	for _, key := range []string{"!api", "api/" + strings.Repeat("a", 125)} {
+		t.Run("invalid key is rejected", func(t *testing.T) {
+			service, _ := newTestService(t, newMemoryRateLimitRepository(), tokenBucketRule)
+
+			resp, err := service.Allow(context.Background(), &api_v1.AllowRequest{Key: key})
+
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+
+	t.Run("cost above the limit is rejected", func(t *testing.T) {
+		service, _ := newTestService(t, newMemoryRateLimitRepository(), tokenBucketRule)
+
+		resp, err := service.Allow(context.Background(), &api_v1.AllowRequest{Key: "api/a", Cost: 11})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("conflict is aborted", func(t *testing.T) {
+		repo := newMemoryRateLimitRepository()
+		repo.err = &interfaces.ConflictError{ID: "api/a", Attempts: 10}
+		service, _ := newTestService(t, repo, tokenBucketRule)
+
+		resp, err := service.Allow(context.Background(), &api_v1.AllowRequest{Key: "api/a"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("closed store is unavailable", func(t *testing.T) {
+		repo := newMemoryRateLimitRepository()
+		repo.err = interfaces.ErrClosed
+		service, _ := newTestService(t, repo, tokenBucketRule)
+
+		resp, err := service.Allow(context.Background(), &api_v1.AllowRequest{Key: "api/a"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
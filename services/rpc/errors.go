@@ -0,0 +1,39 @@
+package rpc
+
+import (
+	"errors"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ToStatus converts a repository error into a gRPC status error
+// - err: error returned by a repository or a mutation
+// Returns a status error with the matching code, errors that already carry a status are returned as is
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, interfaces.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, interfaces.ErrConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, interfaces.ErrOutOfRange):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, interfaces.ErrVersionMismatch):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, interfaces.ErrPreconditionFailed), errors.Is(err, interfaces.ErrTypeMismatch), errors.Is(err, interfaces.ErrLocked):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, interfaces.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, interfaces.ErrClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
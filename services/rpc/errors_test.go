@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "Nil", err: nil, code: codes.OK},
+		{name: "NotFound", err: interfaces.ErrNotFound, code: codes.NotFound},
+		{name: "Conflict", err: &interfaces.ConflictError{ID: "a", Attempts: 3}, code: codes.Aborted},
+		{name: "OutOfRange", err: interfaces.ErrOutOfRange, code: codes.OutOfRange},
+		{name: "VersionMismatch", err: interfaces.ErrVersionMismatch, code: codes.Aborted},
+		{name: "PreconditionFailed", err: interfaces.ErrPreconditionFailed, code: codes.FailedPrecondition},
+		{name: "TypeMismatch", err: interfaces.ErrTypeMismatch, code: codes.FailedPrecondition},
+		{name: "Locked", err: interfaces.ErrLocked, code: codes.FailedPrecondition},
+		{name: "AlreadyExists", err: interfaces.ErrAlreadyExists, code: codes.AlreadyExists},
+		{name: "Closed", err: interfaces.ErrClosed, code: codes.Unavailable},
+		{name: "Status", err: status.Error(codes.InvalidArgument, "bad"), code: codes.InvalidArgument},
+		{name: "Other", err: interfaces.ErrSaveFailed, code: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(ToStatus(tt.err)))
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package rpc
+
+import "regexp"
+
+// namePattern is the grammar counter, lock and sequence names must match, a leading
+// letter or digit followed by letters, digits and the separators . _ - : /
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:/-]{0,127}$`)
+
+// ValidName reports whether a name follows the grammar shared by every service, names can
+// not start with the reserved key prefix so they never reach internal keys
+// - name: string name or name prefix to check
+// Returns true if the name is valid
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{name: "Simple", input: "requests", valid: true},
+		{name: "WithSeparators", input: "tenant-1/api.calls:v2_total", valid: true},
+		{name: "Empty", input: "", valid: false},
+		{name: "LeadingSeparator", input: "/requests", valid: false},
+		{name: "Whitespace", input: "my counter", valid: false},
+		{name: "ReservedPrefix", input: "!internal", valid: false},
+		{name: "TooLong", input: strings.Repeat("a", 129), valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, ValidName(tt.input))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package sequence
+
+import (
+	"context"
+	"log/slog"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/bryopsida/go-grpc-server-template/services/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxCount caps how many IDs a single request can reserve
+const maxCount = 10000
+
+// ServiceImpl is the implementation of SequenceServiceServer
+type ServiceImpl struct {
+	api_v1.UnimplementedSequenceServiceServer
+	repo interfaces.ISequenceRepository
+}
+
+// NewSequenceService creates a new ServiceImpl
+// - repo: ISequenceRepository sequence repository
+func NewSequenceService(repo interfaces.ISequenceRepository) *ServiceImpl {
+	return &ServiceImpl{repo: repo}
+}
+
+// Next reserves a contiguous range of IDs from the named sequence
+// - ctx: context.Context context
+// - req: *api_v1.NextRequest request
+// Returns *api_v1.NextResponse the reserved range, InvalidArgument for bad names or
+// counts, or Unavailable while the server shuts down
+func (s *ServiceImpl) Next(ctx context.Context, req *api_v1.NextRequest) (*api_v1.NextResponse, error) {
+	if !rpc.ValidName(req.GetName()) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid sequence name %q", req.GetName())
+	}
+	count := max(req.GetCount(), 1)
+	if count > maxCount {
+		return nil, status.Errorf(codes.InvalidArgument, "count %d is above %d", count, maxCount)
+	}
+	first, err := s.repo.Next(req.GetName(), count)
+	if err != nil {
+		slog.Error("Error reserving IDs", "sequence", req.GetName(), "count", count, "error", err)
+		return nil, rpc.ToStatus(err)
+	}
+	return &api_v1.NextResponse{First: first, Count: count}, nil
+}
@@ -0,0 +1,94 @@
+package sequence
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api_v1 "github.com/bryopsida/go-grpc-server-template/api/v1"
+	"github.com/bryopsida/go-grpc-server-template/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MockSequenceRepository is a mock of ISequenceRepository using testify/mock
+type MockSequenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockSequenceRepository) Next(name string, count uint64) (uint64, error) {
+	args := m.Called(name, count)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (m *MockSequenceRepository) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func TestNext(t *testing.T) {
+
+	t.Run("reserves a range", func(t *testing.T) {
+		mockRepo := new(MockSequenceRepository)
+		service := NewSequenceService(mockRepo)
+		mockRepo.On("Next", "orders", uint64(5)).Return(uint64(41), nil)
+
+		resp, err := service.Next(context.Background(), &api_v1.NextRequest{Name: "orders", Count: 5})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(41), resp.First)
+		assert.Equal(t, uint64(5), resp.Count)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("count defaults to one", func(t *testing.T) {
+		mockRepo := new(MockSequenceRepository)
+		service := NewSequenceService(mockRepo)
+		mockRepo.On("Next", "orders", uint64(1)).Return(uint64(7), nil)
+
+		resp, err := service.Next(context.Background(), &api_v1.NextRequest{Name: "orders"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), resp.Count)
+	})
+
+	t.Run("invalid requests are rejected", func(t *testing.T) {
+		for name, req := range map[string]*api_v1.NextRequest{
+			"empty name":     {},
+			"reserved name":  {Name: "!orders"},
+			"count too high": {Name: "orders", Count: maxCount + 1},
+		} {
+			mockRepo := new(MockSequenceRepository)
+			service := NewSequenceService(mockRepo)
+
+			resp, err := service.Next(context.Background(), req)
+
+			assert.Nil(t, resp, name)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+	})
+
+	t.Run("closed repository is unavailable", func(t *testing.T) {
+		mockRepo := new(MockSequenceRepository)
+		service := NewSequenceService(mockRepo)
+		mockRepo.On("Next", "orders", uint64(1)).Return(uint64(0), interfaces.ErrClosed)
+
+		resp, err := service.Next(context.Background(), &api_v1.NextRequest{Name: "orders"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("other errors are internal", func(t *testing.T) {
+		mockRepo := new(MockSequenceRepository)
+		service := NewSequenceService(mockRepo)
+		mockRepo.On("Next", "orders", uint64(1)).Return(uint64(0), errors.New("disk full"))
+
+		resp, err := service.Next(context.Background(), &api_v1.NextRequest{Name: "orders"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}